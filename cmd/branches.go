@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/branches"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// BranchesOptions the options for the branches command
+type BranchesOptions struct {
+	*common.CommonOptions
+	ConfigDirectory string
+	Section         string
+	Key             string
+	SettingsFile    string
+	Scheme          string
+	Base            string
+	Remotes         bool
+}
+
+// NewCmdBranches creates the command printing the version of every branch
+func NewCmdBranches(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &BranchesOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "branches",
+		Short: "Prints the version each branch claims, newest first",
+		Long: `Reads the version of every local and remote-tracking branch without checking them out and prints
+them newest first. Branches that set the same version independently are flagged, as are branches whose
+version is older than where they branched off --base.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ConfigDirectory, "config", "c", "Config", "Folder where the ini file holding the version lives.")
+	cmd.Flags().StringVarP(&options.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini file holding the version.")
+	cmd.Flags().StringVarP(&options.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the project or a parent folder.")
+	cmd.Flags().StringVarP(&options.Scheme, "scheme", "", "", "Version scheme, defaults to the scheme of the config file, else four-part when the current version has four numbers and "+scheme.Default+" otherwise.")
+	cmd.Flags().StringVarP(&options.Base, "base", "", "", "Branch the others are compared with for going backwards, defaults to origin/HEAD, main or master.")
+	cmd.Flags().BoolVarP(&options.Remotes, "remotes", "", true, "Include remote-tracking branches.")
+	return cmd
+}
+
+// Run implements the command
+func (o *BranchesOptions) Run() error {
+	projectDir := filepath.Dir(o.ConfigDirectory)
+	cfg, err := config.FindAndLoad(projectDir, o.SettingsFile)
+	if err != nil {
+		return err
+	}
+	path, err := ueini.FindKey(o.ConfigDirectory, o.Section, o.Key)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errors.Errorf("could not find a current %s in any *.ini file in %s", o.Key, o.ConfigDirectory)
+	}
+	name := o.Scheme
+	if name == "" {
+		name = cfg.Scheme
+	}
+	current, err := ueini.Load(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	s, err := scheme.Resolve(name, current.Section(o.Section).Key(o.Key).String())
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		return errors.Wrapf(err, "resolving %s", path)
+	}
+	refs, err := gitutil.Branches(projectDir, o.Remotes)
+	if err != nil {
+		return err
+	}
+	base := o.Base
+	if base == "" {
+		base = defaultBranch(projectDir)
+	}
+	m := &branches.Matrix{
+		Dir:    projectDir,
+		Scheme: s,
+		Base:   base,
+		Read: func(commit string) (string, error) {
+			content, ok, err := gitutil.Show(projectDir, commit, rel)
+			if err != nil || !ok {
+				return "", err
+			}
+			cfg, err := ueini.Load(content)
+			if err != nil {
+				log.Logger().Warnf("Can't read %s at %s: %s", rel, commit, err)
+				return "", nil
+			}
+			return cfg.Section(o.Section).Key(o.Key).String(), nil
+		},
+	}
+	matrix, err := m.Build(refs)
+	if err != nil {
+		return err
+	}
+
+	problems := 0
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BRANCH\tVERSION\tCOMMIT\tPROBLEMS")
+	for _, b := range matrix {
+		version := b.Version
+		if version == "" {
+			version = "-"
+		}
+		if len(b.Problems) > 0 {
+			problems++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", b.Name, version, b.Commit[:7], strings.Join(b.Problems, "; "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if problems > 0 {
+		log.Logger().Warnf("%d of %d branches have version problems", problems, len(matrix))
+	}
+	return nil
+}
+
+// defaultBranch returns the branch origin/HEAD points to, else main or master, empty if there is none
+func defaultBranch(dir string) string {
+	if ref, err := gitutil.Run(dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return ref
+	}
+	for _, name := range []string{"main", "master"} {
+		if _, err := gitutil.Run(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/buildnumber"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// BuildNumberOptions the options shared by the build-number commands
+type BuildNumberOptions struct {
+	*common.CommonOptions
+	ProjectDir   string
+	SettingsFile string
+}
+
+func (o *BuildNumberOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.ProjectDir, "dir", "d", ".", "Folder of the project.")
+	cmd.Flags().StringVarP(&o.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the project or a parent folder.")
+}
+
+func (o *BuildNumberOptions) counter() (*buildnumber.Counter, error) {
+	cfg, err := config.FindAndLoad(o.ProjectDir, o.SettingsFile)
+	if err != nil {
+		return nil, err
+	}
+	counter, _ := buildnumber.FromConfig(cfg, cfg.Root(o.ProjectDir))
+	return counter, nil
+}
+
+// NewCmdBuildNumber creates the command grouping the build-number commands
+func NewCmdBuildNumber(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build-number",
+		Short: "Shows and increments the build number counter",
+		Long: `Shows and increments the build number counter kept in .uvu/build-number.
+
+The counter only ever increases and is incremented every time the version is set once it is enabled in
+the config file or its file exists. Commit the file with the version so every branch continues from it.`,
+	}
+	cmd.AddCommand(newCmdBuildNumberAction(commonOpts, "show", "Prints the last build number handed out", (*BuildNumberOptions).Show))
+	cmd.AddCommand(newCmdBuildNumberAction(commonOpts, "next", "Increments the build number and prints it", (*BuildNumberOptions).Next))
+	return cmd
+}
+
+func newCmdBuildNumberAction(commonOpts *common.CommonOptions, use string, short string, run func(*BuildNumberOptions) error) *cobra.Command {
+	options := &BuildNumberOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := run(options)
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	return cmd
+}
+
+// Show prints the build number
+func (o *BuildNumberOptions) Show() error {
+	counter, err := o.counter()
+	if err != nil {
+		return err
+	}
+	n, err := counter.Current()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(o.Out, n)
+	return nil
+}
+
+// Next increments the build number and prints the new one
+func (o *BuildNumberOptions) Next() error {
+	counter, err := o.counter()
+	if err != nil {
+		return err
+	}
+	n, err := counter.Next()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(o.Out, n)
+	return nil
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdEngines creates the command grouping the engine registration commands
+func NewCmdEngines(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "engines",
+		Short: "Lists and registers the engines installed on this machine",
+	}
+	cmd.AddCommand(NewCmdEnginesList(commonOpts))
+	cmd.AddCommand(NewCmdEnginesRegister(commonOpts))
+	cmd.AddCommand(NewCmdEnginesUnregister(commonOpts))
+	return cmd
+}
+
+// EnginesListOptions the options for the engines list command
+type EnginesListOptions struct {
+	*common.CommonOptions
+}
+
+// NewCmdEnginesList creates the engines list command
+func NewCmdEnginesList(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &EnginesListOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the registered engines and their versions",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the command
+func (o *EnginesListOptions) Run() error {
+	installations, err := engines.Installations()
+	if err != nil {
+		return err
+	}
+	if len(installations) == 0 {
+		log.Logger().Warn("No engines are registered on this machine")
+		return nil
+	}
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "IDENTIFIER\tVERSION\tSOURCE\tPATH")
+	for i := range installations {
+		installation := &installations[i]
+		version := "unknown"
+		buildVersion, err := installation.BuildVersion()
+		if err != nil {
+			log.Logger().Debugf("Unable to read the version of %s: %s", installation.Path, err)
+		} else {
+			version = buildVersion.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", installation.Identifier, version, installation.Source, installation.Path)
+	}
+	return w.Flush()
+}
+
+// EnginesRegisterOptions the options for the engines register command
+type EnginesRegisterOptions struct {
+	*common.CommonOptions
+}
+
+// NewCmdEnginesRegister creates the engines register command
+func NewCmdEnginesRegister(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &EnginesRegisterOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "register <path>",
+		Short: "Registers a source built engine so projects can use it",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the command
+func (o *EnginesRegisterOptions) Run() error {
+	identifier, err := engines.Register(o.Args[0])
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("Registered %s as %s", o.Args[0], utils.ColorInfo(identifier))
+	fmt.Fprintln(o.Out, identifier)
+	return nil
+}
+
+// EnginesUnregisterOptions the options for the engines unregister command
+type EnginesUnregisterOptions struct {
+	*common.CommonOptions
+}
+
+// NewCmdEnginesUnregister creates the engines unregister command
+func NewCmdEnginesUnregister(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &EnginesUnregisterOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "unregister <identifier|path>",
+		Short: "Removes a source built engine from the registered engines",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the command
+func (o *EnginesUnregisterOptions) Run() error {
+	installation, err := engines.Unregister(o.Args[0])
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("Unregistered %s (%s)", utils.ColorInfo(installation.Identifier), installation.Path)
+	return nil
+}
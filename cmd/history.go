@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/history"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// HistoryOptions the options for the history command
+type HistoryOptions struct {
+	*common.CommonOptions
+	ConfigDirectory string
+	Section         string
+	Key             string
+	SettingsFile    string
+	Scheme          string
+	Since           string
+	JSON            bool
+	Find            string
+}
+
+// NewCmdHistory creates the command listing the commits that changed the version
+func NewCmdHistory(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &HistoryOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Lists the commits that changed the version, newest first",
+		Long: `Walks the git history of the ini file holding the version and lists every commit that changed it
+with its author and date, newest first. Commits that set a version no newer than the one before are
+flagged as downgrades. --find prints the commit that introduced a version.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ConfigDirectory, "config", "c", "Config", "Folder where the ini file holding the version lives.")
+	cmd.Flags().StringVarP(&options.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini file holding the version.")
+	cmd.Flags().StringVarP(&options.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the project or a parent folder.")
+	cmd.Flags().StringVarP(&options.Scheme, "scheme", "", "", "Version scheme ordering the versions, defaults to the scheme of the config file, else four-part when the current version has four numbers and "+scheme.Default+" otherwise.")
+	cmd.Flags().StringVarP(&options.Since, "since", "", "", "Only look at commits after a date, such as 2024-01-01 or \"2 weeks ago\".")
+	cmd.Flags().BoolVarP(&options.JSON, "json", "", false, "Print JSON instead of a table.")
+	cmd.Flags().StringVarP(&options.Find, "find", "", "", "Print the commit that introduced this version.")
+	return cmd
+}
+
+// Run implements the command
+func (o *HistoryOptions) Run() error {
+	path, err := ueini.FindKey(o.ConfigDirectory, o.Section, o.Key)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errors.Errorf("could not find a current %s in any *.ini file in %s", o.Key, o.ConfigDirectory)
+	}
+	read := func(content interface{}) (string, error) {
+		cfg, err := ueini.Load(content)
+		if err != nil {
+			return "", err
+		}
+		return cfg.Section(o.Section).Key(o.Key).String(), nil
+	}
+	current, err := read(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	s, err := o.scheme(current)
+	if err != nil {
+		return err
+	}
+	entries, err := history.Changes(filepath.Dir(path), filepath.Base(path), o.Since, s, func(content []byte) (string, error) {
+		return read(content)
+	})
+	if err != nil {
+		return err
+	}
+	if o.Find != "" {
+		return o.find(s, entries)
+	}
+	if o.JSON {
+		return o.printJSON(entries)
+	}
+	if len(entries) == 0 {
+		log.Logger().Infof("No commits changed %s in %s", o.Key, path)
+		return nil
+	}
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tCOMMIT\tAUTHOR\tDATE\tSUBJECT\tNOTE")
+	downgrades := 0
+	for _, e := range entries {
+		note := ""
+		if e.Downgrade {
+			note = utils.ColorWarning("downgrade from " + e.Previous)
+			downgrades++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Version, e.ShortCommit, e.Author, e.Date, e.Subject, note)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if downgrades > 0 {
+		log.Logger().Warnf("%d commits set %s to a %s version no newer than the one before", downgrades, o.Key, s.Name())
+	}
+	return nil
+}
+
+// scheme returns the scheme of the flag or the config file, else the one inferred from the current version
+func (o *HistoryOptions) scheme(current string) (scheme.Scheme, error) {
+	cfg, err := config.FindAndLoad(filepath.Dir(o.ConfigDirectory), o.SettingsFile)
+	if err != nil {
+		return nil, err
+	}
+	name := o.Scheme
+	if name == "" {
+		name = cfg.Scheme
+	}
+	return scheme.Resolve(name, current)
+}
+
+// find prints the oldest commit setting the version, versions that are equal in the scheme match too
+func (o *HistoryOptions) find(s scheme.Scheme, entries []history.Entry) error {
+	wanted, _ := s.Parse(o.Find)
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Version != o.Find {
+			version, err := s.Parse(e.Version)
+			if err != nil || wanted == nil || s.Compare(version, wanted) != 0 {
+				continue
+			}
+		}
+		if o.JSON {
+			return o.printJSON(e)
+		}
+		log.Logger().Infof("%s was introduced by %s on %s by %s: %s", utils.ColorInfo(e.Version), e.ShortCommit, e.Date, e.Author, e.Subject)
+		fmt.Fprintln(o.Out, e.Commit)
+		return nil
+	}
+	return errors.Errorf("no commit set %s to %s", o.Key, o.Find)
+}
+
+func (o *HistoryOptions) printJSON(value interface{}) error {
+	if entries, ok := value.([]history.Entry); ok && entries == nil {
+		value = []history.Entry{}
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "writing JSON")
+	}
+	fmt.Fprintln(o.Out, string(data))
+	return nil
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/lint"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// hookMarker identifies the hooks this tool wrote, so they can be replaced without --force
+const hookMarker = "# Installed by UnrealGameVersionUpdater install-hooks"
+
+// CheckStagedOptions the options for the check-staged command
+type CheckStagedOptions struct {
+	*common.CommonOptions
+	Dir          string
+	Section      string
+	Key          string
+	SettingsFile string
+	Scheme       string
+}
+
+// NewCmdCheckStaged creates the command the pre-commit hook runs
+func NewCmdCheckStaged(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &CheckStagedOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "check-staged",
+		Short: "Validates the staged ini, .uproject and .uplugin files",
+		Long: `Validates the staged ini, .uproject and .uplugin files as they are in the index, so a partially
+staged file is judged by what will be committed. Files must parse, have no merge conflict markers and
+hold a version of the version scheme wherever the version key is set.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "Folder inside the git repository, only files below it are checked.")
+	cmd.Flags().StringVarP(&options.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini files holding the version.")
+	cmd.Flags().StringVarP(&options.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the folder of each file or a parent folder.")
+	cmd.Flags().StringVarP(&options.Scheme, "scheme", "", "", "Version scheme, defaults to the scheme of the config file, else four-part when the current version has four numbers and "+scheme.Default+" otherwise.")
+	return cmd
+}
+
+// Run implements the command
+func (o *CheckStagedOptions) Run() error {
+	files, err := gitutil.StagedFiles(o.Dir)
+	if err != nil {
+		return err
+	}
+	checkers := map[string]*lint.Checker{}
+	failed := 0
+	for _, file := range files {
+		if !lint.Supports(file) {
+			continue
+		}
+		content, err := gitutil.ShowStaged(o.Dir, file)
+		if err != nil {
+			return err
+		}
+		checker, err := o.checker(checkers, filepath.Dir(filepath.Join(o.Dir, file)))
+		if err != nil {
+			return err
+		}
+		problems := checker.Check(file, content)
+		for _, problem := range problems {
+			log.Logger().Error(problem.Error())
+		}
+		if len(problems) > 0 {
+			failed++
+		} else {
+			log.Logger().Debugf("%s is valid", file)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d staged files have problems, fix and stage them again", failed)
+	}
+	return nil
+}
+
+// checker returns the checker for the config file that applies to dir, loading each config only once
+func (o *CheckStagedOptions) checker(checkers map[string]*lint.Checker, dir string) (*lint.Checker, error) {
+	path := o.SettingsFile
+	if path == "" {
+		var err error
+		if path, err = config.Find(dir); err != nil {
+			return nil, err
+		}
+	}
+	if checker, ok := checkers[path]; ok {
+		return checker, nil
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	name := o.Scheme
+	if name == "" {
+		name = cfg.Scheme
+	}
+	checker := &lint.Checker{Version: guard.IniKey{Section: o.Section, Key: o.Key}}
+	if name != "" {
+		if checker.Scheme, err = scheme.Get(name); err != nil {
+			return nil, err
+		}
+	}
+	checkers[path] = checker
+	return checker, nil
+}
+
+// InstallHooksOptions the options for the install-hooks command
+type InstallHooksOptions struct {
+	*common.CommonOptions
+	Dir     string
+	Command string
+	Force   bool
+	DryRun  bool
+}
+
+// NewCmdInstallHooks creates the command writing the pre-commit hook
+func NewCmdInstallHooks(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &InstallHooksOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "install-hooks",
+		Short: "Writes a git pre-commit hook that runs check-staged",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "Folder inside the git repository.")
+	cmd.Flags().StringVarP(&options.Command, "command", "", "", "The command the hook runs, defaults to the path of this executable.")
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Replace a pre-commit hook that wasn't written by this tool.")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the hook without writing it.")
+	return cmd
+}
+
+// Run implements the command
+func (o *InstallHooksOptions) Run() error {
+	hooks, err := gitutil.Run(o.Dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return errors.Wrapf(err, "%s is not in a git repository", o.Dir)
+	}
+	if !filepath.IsAbs(hooks) {
+		hooks = filepath.Join(o.Dir, hooks)
+	}
+	path := filepath.Join(hooks, "pre-commit")
+	command := o.Command
+	if command == "" {
+		if command, err = os.Executable(); err != nil {
+			return errors.Wrap(err, "finding this executable, pass --command")
+		}
+		command = filepath.ToSlash(command)
+	}
+	if existing, err := ioutil.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) && !o.Force {
+		return errors.Errorf("%s already exists, pass --force to replace it", path)
+	}
+
+	hook := fmt.Sprintf("#!/bin/sh\n%s\n# Validates the staged ini, .uproject and .uplugin files\nexec %q check-staged\n", hookMarker, command)
+	plan := edit.NewPlan()
+	err = plan.Edit(path, "pre-commit hook", func([]byte) ([]byte, error) {
+		return []byte(hook), nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := applyPlan(o.Out, plan, o.DryRun); err != nil || o.DryRun {
+		return err
+	}
+	if err := os.Chmod(path, 0755); err != nil {
+		return errors.Wrapf(err, "making %s executable", path)
+	}
+	log.Logger().Infof("Installed the pre-commit hook %s", utils.ColorInfo(path))
+	return nil
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// IniOptions the options for the ini commands
+type IniOptions struct {
+	*common.CommonOptions
+	File           string
+	Section        string
+	Key            string
+	SettingsFile   string
+	DryRun         bool
+	AllowDowngrade bool
+}
+
+func (o *IniOptions) addFlags(cmd *cobra.Command, keyRequired bool) {
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "The ini file to edit, such as Config/DefaultEngine.ini.")
+	cmd.Flags().StringVarP(&o.Section, "section", "s", "", "The section holding the key, such as /Script/Engine.Engine.")
+	cmd.Flags().StringVarP(&o.Key, "key", "k", "", "The key, prefixed with +, ., - or ! to edit an array the way unreal does.")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("section")
+	if keyRequired {
+		_ = cmd.MarkFlagRequired("key")
+	}
+}
+
+// NewCmdIni creates the command grouping the ini commands
+func NewCmdIni(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ini",
+		Short: "Reads and edits any key of an unreal config file",
+		Long: `Reads and edits any key of an unreal config file, keeping quotes, comments and repeated array keys intact.
+
+Keys may start with an array operator:
+  +Key  adds the value unless the array already has it
+  .Key  adds the value even if the array already has it
+  -Key  removes the value
+  !Key  clears the array, the values given are then added with +Key`,
+	}
+	cmd.AddCommand(NewCmdIniGet(commonOpts))
+	cmd.AddCommand(NewCmdIniSet(commonOpts))
+	cmd.AddCommand(NewCmdIniUnset(commonOpts))
+	return cmd
+}
+
+// NewCmdIniGet creates the ini get command
+func NewCmdIniGet(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &IniOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Prints the values of a key, one per line, or every line of the section",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Get()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd, false)
+	return cmd
+}
+
+// NewCmdIniSet creates the ini set command
+func NewCmdIniSet(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &IniOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "set <value>...",
+		Short: "Sets a key, or adds and removes array values with an operator",
+		Args:  cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Set()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd, true)
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the diff without writing anything.")
+	cmd.Flags().BoolVarP(&options.AllowDowngrade, "allow-downgrade", "", false, "Write a ProjectVersion or StoreVersion older than or the same as the current one.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file with the scheme of ProjectVersion, defaults to .uvu/config.yaml in the project or a parent folder.")
+	return cmd
+}
+
+// NewCmdIniUnset creates the ini unset command
+func NewCmdIniUnset(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &IniOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "unset [value]...",
+		Short: "Removes a key, or only the lines holding the values given",
+		Long:  "Removes the lines of the key from the file. Without an operator every line of the key is removed, including its array operators, with one only the lines using it. If values are given only the lines holding them are removed.",
+		Args:  cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Unset()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd, true)
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the diff without writing anything.")
+	return cmd
+}
+
+// Get implements the ini get command
+func (o *IniOptions) Get() error {
+	cfg, err := ueini.Load(o.File)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", o.File)
+	}
+	section, err := cfg.GetSection(o.Section)
+	if err != nil {
+		return errors.Errorf("%s has no section [%s]", o.File, o.Section)
+	}
+	if o.Key == "" {
+		for _, key := range section.Keys() {
+			for _, value := range key.ValueWithShadows() {
+				fmt.Fprintf(o.Out, "%s=%s\n", key.Name(), value)
+			}
+		}
+		return nil
+	}
+	values := ueini.Get(section, o.Key)
+	if len(values) == 0 {
+		return errors.Errorf("[%s] in %s has no %s", o.Section, o.File, o.Key)
+	}
+	for _, value := range values {
+		fmt.Fprintln(o.Out, value)
+	}
+	return nil
+}
+
+// Set implements the ini set command
+func (o *IniOptions) Set() error {
+	return o.edit(func(content []byte) ([]byte, error) {
+		values, err := o.guard(content)
+		if err != nil {
+			return nil, err
+		}
+		return ueini.SetValues(content, o.Section, o.Key, values)
+	})
+}
+
+// guard refuses to replace the version or one of guard.MonotonicIniKeys with a value that isn't newer,
+// the same way setting the version does. It returns the values to write, with ProjectVersion normalized.
+func (o *IniOptions) guard(content []byte) ([]string, error) {
+	op, key := ueini.SplitKey(o.Key)
+	if op != ueini.OpSet || len(o.Args) == 0 {
+		return o.Args, nil
+	}
+	cfg, err := ueini.Load(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s", o.File)
+	}
+	current := cfg.Section(o.Section).Key(key).String()
+	last := len(o.Args) - 1
+	g := &guard.Guard{AllowDowngrade: o.AllowDowngrade}
+	if o.Section != ueini.GeneralProjectSettings || !strings.EqualFold(key, ProjectVersionKey) {
+		return o.Args, g.IniKey(o.File, o.Section, key, current, o.Args[last])
+	}
+	projectConfig, err := config.FindAndLoad(filepath.Dir(filepath.Dir(o.File)), o.SettingsFile)
+	if err != nil {
+		return nil, err
+	}
+	version, err := checkVersion(projectConfig, g, o.File, current, o.Args[last])
+	if err != nil {
+		return nil, err
+	}
+	values := append(append([]string{}, o.Args[:last]...), version.String())
+	return values, nil
+}
+
+// Unset implements the ini unset command
+func (o *IniOptions) Unset() error {
+	if _, err := os.Stat(o.File); err != nil {
+		return errors.Wrapf(err, "reading %s", o.File)
+	}
+	return o.edit(func(content []byte) ([]byte, error) {
+		return ueini.UnsetValues(content, o.Section, o.Key, o.Args)
+	})
+}
+
+func (o *IniOptions) edit(fn func(content []byte) ([]byte, error)) error {
+	plan := edit.NewPlan()
+	if err := plan.Edit(o.File, o.Key, fn); err != nil {
+		return err
+	}
+	return applyPlan(o.Out, plan, o.DryRun)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIniOptions_SetProjectVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Config", "DefaultGame.ini")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.0.0\n"), 0644))
+	settingsFile := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(settingsFile, []byte("scheme: four-part\n"), 0644))
+	set := func(value string, settings string) error {
+		o := &IniOptions{
+			CommonOptions: &common.CommonOptions{Out: os.Stdout, Args: []string{value}},
+			File:          path,
+			Section:       ueini.GeneralProjectSettings,
+			Key:           ProjectVersionKey,
+			SettingsFile:  settings,
+		}
+		return o.Set()
+	}
+
+	// the input is normalized the way setting the version does
+	assert.NoError(t, set("v1.2.0", ""))
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "ProjectVersion=1.2.0\n")
+
+	assert.True(t, guard.IsDowngrade(set("release-1.1.0", "")))
+	assert.Error(t, set("1.3.0", settingsFile), "the scheme of --settings is four-part")
+}
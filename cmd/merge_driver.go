@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/inimerge"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// MergeDriverName is the name of the merge driver in git config and .gitattributes
+	MergeDriverName = "uvu-ini"
+
+	// mergeDriverArgs are the placeholders git replaces with the ancestor, ours, theirs and the path. git
+	// quotes the path it puts in for %P itself, quoting it again would split paths with spaces.
+	mergeDriverArgs = "merge-driver %O %A %B %P"
+)
+
+// MergeDriverOptions the options for the merge-driver command
+type MergeDriverOptions struct {
+	*common.CommonOptions
+	Section      string
+	Key          string
+	SettingsFile string
+	Scheme       string
+}
+
+// NewCmdMergeDriver creates the git merge driver for ini files
+func NewCmdMergeDriver(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &MergeDriverOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "merge-driver <ancestor> <ours> <theirs> [path]",
+		Short: "A git merge driver for unreal ini files that keeps the higher version",
+		Long: `A git merge driver for unreal ini files, set it up with install-merge-driver.
+
+The version key takes the higher version of the two branches following the version scheme, and keys
+rendered from templates take the value of the branch whose version won. Whole numbers that only
+increase, such as the Android StoreVersion, take the larger value. The rest of the file is merged line
+by line like git does, the result is written to <ours>.`,
+		Args: cobra.RangeArgs(3, 4),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini file holding the version.")
+	cmd.Flags().StringVarP(&options.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the folder of the file or a parent folder.")
+	cmd.Flags().StringVarP(&options.Scheme, "scheme", "", "", "Version scheme, defaults to the scheme of the config file, else four-part when the current version has four numbers and "+scheme.Default+" otherwise.")
+	return cmd
+}
+
+// Run implements the command
+func (o *MergeDriverOptions) Run() error {
+	var contents [][]byte
+	for _, path := range o.Args[:3] {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		}
+		contents = append(contents, data)
+	}
+	name := o.Args[1]
+	if len(o.Args) > 3 {
+		name = o.Args[3]
+	}
+	resolver, err := o.resolver(filepath.Dir(name))
+	if err != nil {
+		return err
+	}
+	merged, conflicts, err := resolver.Merge(contents[0], contents[1], contents[2])
+	if err != nil {
+		return errors.Wrapf(err, "merging %s", name)
+	}
+	if err := ioutil.WriteFile(o.Args[1], merged, 0644); err != nil {
+		return errors.Wrapf(err, "writing %s", o.Args[1])
+	}
+	if conflicts > 0 {
+		return errors.Errorf("%d conflicts left in %s", conflicts, name)
+	}
+	return nil
+}
+
+// resolver settles the version key, the keys rendered from templates and the keys that only increase
+func (o *MergeDriverOptions) resolver(dir string) (*inimerge.Resolver, error) {
+	cfg, err := config.FindAndLoad(dir, o.SettingsFile)
+	if err != nil {
+		return nil, err
+	}
+	name := o.Scheme
+	if name == "" {
+		name = cfg.Scheme
+	}
+	answer := &inimerge.Resolver{
+		Version:   guard.IniKey{Section: o.Section, Key: o.Key},
+		Monotonic: guard.MonotonicIniKeys,
+	}
+	for _, t := range cfg.Templates {
+		section := t.Section
+		if section == "" {
+			section = ueini.GeneralProjectSettings
+		}
+		answer.Followers = append(answer.Followers, guard.IniKey{Section: section, Key: t.Key})
+	}
+	if name != "" {
+		if answer.Scheme, err = scheme.Get(name); err != nil {
+			return nil, err
+		}
+	}
+	return answer, nil
+}
+
+// InstallMergeDriverOptions the options for the install-merge-driver command
+type InstallMergeDriverOptions struct {
+	*common.CommonOptions
+	Dir     string
+	Pattern string
+	Command string
+	Global  bool
+	DryRun  bool
+}
+
+// NewCmdInstallMergeDriver creates the command registering the merge driver with git
+func NewCmdInstallMergeDriver(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &InstallMergeDriverOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "install-merge-driver",
+		Short: "Registers merge-driver in git config and .gitattributes",
+		Long: `Adds the merge driver to the git config of the repository and assigns it to the ini files in
+.gitattributes. Commit .gitattributes so everyone uses it; each clone needs the git config, which git
+doesn't share.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "Folder inside the git repository.")
+	cmd.Flags().StringVarP(&options.Pattern, "pattern", "", "*.ini", "The .gitattributes pattern of the files to merge.")
+	cmd.Flags().StringVarP(&options.Command, "command", "", "", "The command git runs, defaults to the path of this executable.")
+	cmd.Flags().BoolVarP(&options.Global, "global", "", false, "Write the driver to the global git config instead of the repository's.")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the changes without writing anything.")
+	return cmd
+}
+
+// Run implements the command
+func (o *InstallMergeDriverOptions) Run() error {
+	up, err := gitutil.Run(o.Dir, "rev-parse", "--show-cdup")
+	if err != nil {
+		return errors.Wrapf(err, "%s is not in a git work tree", o.Dir)
+	}
+	command := o.Command
+	if command == "" {
+		if command, err = os.Executable(); err != nil {
+			return errors.Wrap(err, "finding this executable, pass --command")
+		}
+		command = filepath.ToSlash(command)
+	}
+	driver := mergeDriverCommand(command)
+	scope := "--local"
+	if o.Global {
+		scope = "--global"
+	}
+	settings := [][]string{
+		{"merge." + MergeDriverName + ".name", "unreal ini files keeping the higher version"},
+		{"merge." + MergeDriverName + ".driver", driver},
+	}
+
+	root := filepath.Join(o.Dir, up)
+	attributes := filepath.Join(root, ".gitattributes")
+	line := o.Pattern + " merge=" + MergeDriverName
+	plan := edit.NewPlan()
+	err = plan.Edit(attributes, "merge driver", func(content []byte) ([]byte, error) {
+		for _, existing := range strings.Split(string(content), "\n") {
+			if strings.TrimSpace(existing) == line {
+				return content, nil
+			}
+		}
+		if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+			content = append(content, '\n')
+		}
+		return append(content, line+"\n"...), nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := applyPlan(o.Out, plan, o.DryRun); err != nil {
+		return err
+	}
+	for _, setting := range settings {
+		if o.DryRun {
+			log.Logger().Infof("Would run git config %s %s %q", scope, setting[0], setting[1])
+			continue
+		}
+		if _, err := gitutil.Run(root, "config", scope, setting[0], setting[1]); err != nil {
+			return err
+		}
+	}
+	if !o.DryRun {
+		log.Logger().Infof("Installed the %s merge driver for %s", utils.ColorInfo(MergeDriverName), o.Pattern)
+	}
+	return nil
+}
+
+// mergeDriverCommand returns the command line git runs to merge a file with the executable at command
+func mergeDriverCommand(command string) string {
+	return fmt.Sprintf("%q %s", command, mergeDriverArgs)
+}
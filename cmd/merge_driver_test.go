@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeDriverCommand_PathWithSpaces(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake driver is a shell script")
+	}
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		_, err := gitutil.Run(repo, args...)
+		assert.NoError(t, err)
+	}
+	path := filepath.Join("My Game", "Config", "DefaultGame.ini")
+	commit := func(content string, message string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(repo, path), []byte(content), 0644))
+		run("commit", "-q", "-am", message)
+	}
+
+	// the fake driver records how git split its arguments
+	args := filepath.Join(dir, "args")
+	driver := filepath.Join(dir, "driver.sh")
+	assert.NoError(t, ioutil.WriteFile(driver, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > "+args+"\n"), 0755))
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(repo, "My Game", "Config"), 0755))
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	run("config", "commit.gpgsign", "false")
+	run("config", "merge."+MergeDriverName+".driver", mergeDriverCommand(driver))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(repo, ".gitattributes"), []byte("*.ini merge="+MergeDriverName+"\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(repo, path), []byte("ProjectVersion=1.0.0\n"), 0644))
+	run("add", "-A")
+	run("commit", "-q", "-m", "base")
+	run("checkout", "-q", "-b", "feature")
+	commit("ProjectVersion=1.1.0\n", "feature")
+	run("checkout", "-q", "-")
+	commit("ProjectVersion=1.0.1\n", "hotfix")
+	run("merge", "-q", "--no-edit", "feature")
+
+	data, err := ioutil.ReadFile(args)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if assert.Len(t, lines, 5) {
+		assert.Equal(t, "merge-driver", lines[0])
+		assert.Equal(t, filepath.ToSlash(path), lines[4])
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/modules"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ModulesOptions the options for the modules command
+type ModulesOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	EngineDir string
+	BuildID   string
+	Fix       bool
+	DryRun    bool
+}
+
+// NewCmdModules creates the command checking the BuildId of the project's and plugins' binaries
+func NewCmdModules(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &ModulesOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "modules",
+		Short: "Reports the BuildId of the .modules manifests of the project and its plugins",
+		Long:  "Reads every Binaries/<Platform>/*.modules manifest of the project and its plugins and compares its BuildId with the engine's manifest of the same name, or the project's own when the engine isn't installed. The editor refuses to load binaries with another BuildId. With --fix the mismatched manifests are rewritten.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().StringVarP(&options.EngineDir, "engine-dir", "", "", "Root of the engine whose BuildIds are expected, defaults to the engine the project is associated with.")
+	cmd.Flags().StringVarP(&options.BuildID, "build-id", "", "", "BuildId every manifest must have instead of the engine's.")
+	cmd.Flags().BoolVarP(&options.Fix, "fix", "", false, "Rewrite the BuildId of mismatched manifests to the expected one.")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the diff of --fix without writing anything.")
+	return cmd
+}
+
+// Run implements the command
+func (o *ModulesOptions) Run() error {
+	project, err := o.loadProject()
+	if err != nil {
+		return err
+	}
+	own, err := modules.LoadAll(project.Dir())
+	if err != nil {
+		return err
+	}
+	pluginPaths, err := descriptor.FindPlugins(project.Dir())
+	if err != nil {
+		return err
+	}
+	var pluginDirs []string
+	for _, path := range pluginPaths {
+		pluginDirs = append(pluginDirs, filepath.Dir(path))
+	}
+	plugins, err := modules.LoadAll(pluginDirs...)
+	if err != nil {
+		return err
+	}
+	manifests := append(own, plugins...)
+	if len(manifests) == 0 {
+		log.Logger().Infof("%s has no compiled binaries", project.Name())
+		return nil
+	}
+
+	expected, err := o.expected(project, own)
+	if err != nil {
+		return err
+	}
+
+	plan := edit.NewPlan()
+	problems := 0
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "MANIFEST\tBUILD ID\tEXPECTED\tSTATUS")
+	for _, manifest := range manifests {
+		want, status := o.expectedBuildID(expected, manifest), "match"
+		switch {
+		case want == "":
+			status = "unknown"
+		case manifest.BuildID != want:
+			status = utils.ColorWarning("mismatch")
+			problems++
+			if o.Fix {
+				err := plan.Edit(manifest.Path, "BuildId", func(content []byte) ([]byte, error) {
+					return modules.SetBuildID(content, want)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", relativePath(project.Dir(), manifest.Path), manifest.BuildID, want, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if o.Fix {
+		if err := applyPlan(o.Out, plan, o.DryRun); err != nil {
+			return err
+		}
+		if !o.DryRun {
+			return nil
+		}
+	}
+	if problems > 0 {
+		return errors.Errorf("%d module manifests don't have the expected BuildId", problems)
+	}
+	return nil
+}
+
+// expected returns the BuildId of each manifest key: the engine's, else the one of the project's own
+// binaries
+func (o *ModulesOptions) expected(project *descriptor.Project, own []*modules.Manifest) (map[string]string, error) {
+	if o.BuildID != "" {
+		log.Logger().Infof("Checking module manifests against BuildId %s", utils.ColorInfo(o.BuildID))
+		return nil, nil
+	}
+	engineDir := o.EngineDir
+	if engineDir == "" {
+		dir, err := engines.ResolveDir(project.EngineAssociation, project.Dir())
+		if err != nil {
+			log.Logger().Warnf("Checking module manifests against the project's binaries only: %s", err)
+			return modules.Expected(own), nil
+		}
+		engineDir = dir
+	}
+	engine, err := modules.LoadAll(filepath.Join(engineDir, "Engine"))
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Infof("Checking module manifests against the engine in %s", utils.ColorInfo(engineDir))
+	return modules.Expected(engine, own), nil
+}
+
+func (o *ModulesOptions) expectedBuildID(expected map[string]string, manifest *modules.Manifest) string {
+	if o.BuildID != "" {
+		return o.BuildID
+	}
+	return expected[manifest.Key()]
+}
+
+// relativePath shows a path relative to dir when it is inside it
+func relativePath(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
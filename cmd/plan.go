@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+)
+
+// printDiff writes the plan as a colored unified diff
+func printDiff(out io.Writer, plan *edit.Plan) {
+	for _, line := range strings.SplitAfter(plan.Diff(), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Fprint(out, utils.ColorBold(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Fprint(out, utils.ColorAnswer(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Fprint(out, utils.ColorInfo(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Fprint(out, utils.ColorError(line))
+		default:
+			fmt.Fprint(out, line)
+		}
+	}
+}
+
+// applyPlan shows the plan and applies it unless this is a dry run
+func applyPlan(out io.Writer, plan *edit.Plan, dryRun bool) error {
+	if plan.Empty() {
+		log.Logger().Info("Nothing to change")
+		return nil
+	}
+	printDiff(out, plan)
+	if dryRun {
+		log.Logger().Infof("Dry run, %s would change", utils.Pluralize(len(plan.Changes()), "file", "files"))
+		return nil
+	}
+	if err := plan.Apply(); err != nil {
+		return err
+	}
+	log.Logger().Infof("Updated %s", utils.Pluralize(len(plan.Changes()), "file", "files"))
+	return nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdPlugins creates the command grouping the plugin descriptor commands
+func NewCmdPlugins(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Inspects the .uplugin descriptors of the project",
+	}
+	cmd.AddCommand(NewCmdPluginsCheck(commonOpts))
+	return cmd
+}
+
+// PluginsCheckOptions the options for the plugins check command
+type PluginsCheckOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	EngineVersion string
+	Fix           bool
+	DryRun        bool
+}
+
+// NewCmdPluginsCheck creates the plugins check command
+func NewCmdPluginsCheck(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &PluginsCheckOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Reports plugins whose EngineVersion doesn't match the project's engine",
+		Long:  "Resolves the EngineAssociation of the .uproject to an engine version and reports every plugin built for an older or newer engine. With --fix the EngineVersion of first-party plugins is rewritten to match.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().StringVarP(&options.EngineVersion, "engine-version", "e", "", "Engine version to check against instead of the one the project is associated with.")
+	cmd.Flags().BoolVarP(&options.Fix, "fix", "", false, "Rewrite the EngineVersion of first-party plugins to the project's engine version.")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the diff of --fix without writing anything.")
+	return cmd
+}
+
+// Run implements the command
+func (o *PluginsCheckOptions) Run() error {
+	project, err := o.loadProject()
+	if err != nil {
+		return err
+	}
+	engine, err := o.engineVersion(project)
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("Checking plugins against engine %s", utils.ColorInfo(engine.String()))
+
+	plugins, err := descriptor.LoadPlugins(project.Dir())
+	if err != nil {
+		return err
+	}
+
+	problems, fixed := 0, 0
+	plan := edit.NewPlan()
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PLUGIN\tENGINE VERSION\tSTATUS\tFIRST PARTY")
+	for _, plugin := range plugins {
+		status := plugin.CheckCompatibility(engine)
+		if status.IsProblem() {
+			problems++
+			if o.Fix && plugin.IsFirstParty() {
+				if err := planPluginEngineVersion(plan, plugin, engine.String()); err != nil {
+					return err
+				}
+				fixed++
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", plugin.Name(), plugin.EngineVersion, colorCompatibility(status), plugin.IsFirstParty())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if o.Fix {
+		if err := applyPlan(o.Out, plan, o.DryRun); err != nil {
+			return err
+		}
+		if !o.DryRun {
+			problems -= fixed
+		}
+	}
+	if problems > 0 {
+		return errors.Errorf("%d plugins are not compatible with engine %s", problems, engine)
+	}
+	return nil
+}
+
+func (o *PluginsCheckOptions) engineVersion(project *descriptor.Project) (*engines.BuildVersion, error) {
+	if o.EngineVersion != "" {
+		return engines.ParseEngineVersion(o.EngineVersion)
+	}
+	return engines.Resolve(project.EngineAssociation, project.Dir())
+}
+
+// planPluginEngineVersion plans rewriting the EngineVersion of the plugin
+func planPluginEngineVersion(plan *edit.Plan, plugin *descriptor.Plugin, version string) error {
+	log.Logger().Infof("Setting %s EngineVersion from %s to %s", plugin.Name(), plugin.EngineVersion, utils.ColorInfo(version))
+	return plan.Edit(plugin.Path, descriptor.EngineVersionKey, func(content []byte) ([]byte, error) {
+		return descriptor.SetField(content, descriptor.EngineVersionKey, version)
+	})
+}
+
+func colorCompatibility(status descriptor.Compatibility) string {
+	if status.IsProblem() {
+		return utils.ColorWarning(string(status))
+	}
+	return string(status)
+}
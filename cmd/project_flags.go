@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/spf13/cobra"
+)
+
+// ProjectFlags locate the .uproject a command works on
+type ProjectFlags struct {
+	ProjectDir  string
+	ProjectFile string
+}
+
+func (f *ProjectFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.ProjectDir, "dir", "d", ".", "Folder containing the .uproject file.")
+	cmd.Flags().StringVarP(&f.ProjectFile, "uproject", "u", "", "Path to the .uproject file, defaults to the only one in --dir.")
+}
+
+func (f *ProjectFlags) projectFile() (string, error) {
+	if f.ProjectFile != "" {
+		return f.ProjectFile, nil
+	}
+	return descriptor.FindProject(f.ProjectDir)
+}
+
+func (f *ProjectFlags) loadProject() (*descriptor.Project, error) {
+	path, err := f.projectFile()
+	if err != nil {
+		return nil, err
+	}
+	return descriptor.LoadProject(path)
+}
+
+// configDir returns the folder holding the project's ini files, the Config folder next to the .uproject
+// unless it was overridden
+func (f *ProjectFlags) configDir(override string) string {
+	if override != "" {
+		return override
+	}
+	dir := f.ProjectDir
+	if f.ProjectFile != "" {
+		dir = filepath.Dir(f.ProjectFile)
+	}
+	return filepath.Join(dir, "Config")
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/settings"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ProjectIDOptions the options shared by the project-id commands
+type ProjectIDOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	ConfigDir string
+}
+
+func (o *ProjectIDOptions) addFlags(cmd *cobra.Command) {
+	o.ProjectFlags.addFlags(cmd)
+	cmd.Flags().StringVarP(&o.ConfigDir, "config", "c", "", "Folder holding the project's ini files, defaults to the Config folder next to the .uproject.")
+}
+
+// load returns the ini file holding GeneralProjectSettings and its content
+func (o *ProjectIDOptions) load() (string, *settings.GeneralProjectSettings, error) {
+	path, err := settings.Find(o.configDir(o.ConfigDir))
+	if err != nil {
+		return "", nil, err
+	}
+	projectSettings, err := settings.Load(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, projectSettings, nil
+}
+
+// NewCmdProjectID creates the command grouping the ProjectID commands
+func NewCmdProjectID(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project-id",
+		Short: "Shows, validates and regenerates the ProjectID of GeneralProjectSettings",
+	}
+	cmd.AddCommand(newCmdProjectIDAction(commonOpts, "show", "Prints the ProjectID", (*ProjectIDOptions).Show))
+	cmd.AddCommand(newCmdProjectIDAction(commonOpts, "validate", "Checks the ProjectID is a GUID of 32 hex digits", (*ProjectIDOptions).Validate))
+	cmd.AddCommand(newCmdProjectIDAction(commonOpts, "regenerate", "Replaces the ProjectID with a new random GUID", (*ProjectIDOptions).Regenerate))
+	cmd.AddCommand(NewCmdProjectIDDuplicates(commonOpts))
+	return cmd
+}
+
+func newCmdProjectIDAction(commonOpts *common.CommonOptions, use string, short string, run func(*ProjectIDOptions) error) *cobra.Command {
+	options := &ProjectIDOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := run(options)
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	return cmd
+}
+
+// Show prints the ProjectID
+func (o *ProjectIDOptions) Show() error {
+	path, projectSettings, err := o.load()
+	if err != nil {
+		return err
+	}
+	if projectSettings.ProjectID == "" {
+		return errors.Errorf("%s has no %s", path, settings.ProjectIDKey)
+	}
+	fmt.Fprintln(o.Out, projectSettings.ProjectID)
+	return nil
+}
+
+// Validate checks the format of the ProjectID
+func (o *ProjectIDOptions) Validate() error {
+	path, projectSettings, err := o.load()
+	if err != nil {
+		return err
+	}
+	if err := settings.ValidateProjectID(projectSettings.ProjectID); err != nil {
+		return errors.Wrapf(err, "checking %s", path)
+	}
+	log.Logger().Infof("ProjectID %s is valid", utils.ColorInfo(projectSettings.ProjectID))
+	return nil
+}
+
+// Regenerate writes a new random ProjectID
+func (o *ProjectIDOptions) Regenerate() error {
+	path, projectSettings, err := o.load()
+	if err != nil {
+		return err
+	}
+	id, err := settings.NewProjectID()
+	if err != nil {
+		return errors.Wrap(err, "generating a ProjectID")
+	}
+	plan := edit.NewPlan()
+	content, err := plan.Content(path)
+	if err != nil {
+		return err
+	}
+	content, err = ueini.SetKey(content, ueini.GeneralProjectSettings, settings.ProjectIDKey, string(id))
+	if err != nil {
+		return errors.Wrapf(err, "updating %s", path)
+	}
+	if err := plan.Update(path, content, settings.ProjectIDKey); err != nil {
+		return err
+	}
+	if err := applyPlan(o.Out, plan, false); err != nil {
+		return err
+	}
+	if projectSettings.ProjectID != "" {
+		log.Logger().Infof("Changed ProjectID from %s to %s", projectSettings.ProjectID, utils.ColorInfo(id))
+	}
+	return nil
+}
+
+// ProjectIDDuplicatesOptions the options for the project-id duplicates command
+type ProjectIDDuplicatesOptions struct {
+	*common.CommonOptions
+}
+
+// NewCmdProjectIDDuplicates creates the command that looks for projects sharing a ProjectID
+func NewCmdProjectIDDuplicates(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &ProjectIDDuplicatesOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "duplicates [root]",
+		Short: "Finds projects below root sharing the same ProjectID",
+		Long:  "Searches a monorepo for every .uproject and fails if two of them have the same ProjectID, which happens when a template project is copied.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the command
+func (o *ProjectIDDuplicatesOptions) Run() error {
+	root := "."
+	if len(o.Args) > 0 {
+		root = o.Args[0]
+	}
+	projects, err := settings.FindProjectIDs(root)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if err := settings.ValidateProjectID(project.ProjectID); err != nil {
+			log.Logger().Warnf("%s: %s", project.Project, err)
+		}
+	}
+	duplicates := settings.DuplicateProjectIDs(projects)
+	if len(duplicates) == 0 {
+		log.Logger().Infof("All %d projects have a unique ProjectID", len(projects))
+		return nil
+	}
+	var ids []string
+	for id := range duplicates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(o.Out, "%s\n  %s\n", utils.ColorWarning(id), strings.Join(duplicates[id], "\n  "))
+	}
+	return errors.Errorf("%d ProjectIDs are shared by more than one project, run `project-id regenerate` in the copies", len(ids))
+}
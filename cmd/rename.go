@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/rename"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RenameOptions the options for the rename command
+type RenameOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	ConfigDir string
+	DryRun    bool
+	Force     bool
+}
+
+// NewCmdRename creates the command that renames the project
+func NewCmdRename(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &RenameOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "rename <NewName>",
+		Short: "Renames the project and the modules named after it",
+		Long:  "Sets ProjectName, renames the .uproject, the modules and targets named after the project and adds ActiveGameNameRedirects to DefaultEngine.ini so existing assets keep loading. Refuses to run with uncommitted git changes so the rename can be reviewed and reverted on its own.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().StringVarP(&options.ConfigDir, "config", "c", "", "Folder holding the project's ini files, defaults to the Config folder next to the .uproject.")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only show the diff of the planned edits.")
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Rename even when there are uncommitted changes or the project is not in a git repository.")
+	return cmd
+}
+
+// Run implements the command
+func (o *RenameOptions) Run() error {
+	project, err := o.loadProject()
+	if err != nil {
+		return err
+	}
+	if !o.DryRun && !o.Force {
+		if err := gitutil.RequireClean(project.Dir()); err != nil {
+			return errors.Wrap(err, "refusing to rename, use --force to skip this check")
+		}
+	}
+	configDir := o.configDir(o.ConfigDir)
+	log.Logger().Infof("Renaming %s to %s", utils.ColorInfo(project.Name()), utils.ColorInfo(o.Args[0]))
+	plan, err := rename.Plan(project, configDir, o.Args[0])
+	if err != nil {
+		return err
+	}
+	return applyPlan(o.Out, plan, o.DryRun)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/pathfilter"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// maxListedFiles is how many changed files an error lists before summing up the rest
+const maxListedFiles = 10
+
+// RequireBumpOptions the options for the require-bump command
+type RequireBumpOptions struct {
+	*common.CommonOptions
+	Base            string
+	ConfigDirectory string
+	Section         string
+	Key             string
+	SettingsFile    string
+	Scheme          string
+	Paths           []string
+	Ignore          []string
+}
+
+// NewCmdRequireBump creates the command failing when game files changed without a new version
+func NewCmdRequireBump(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &RequireBumpOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "require-bump",
+		Short: "Fails if game files changed since the base ref without a newer version",
+		Long: `Compares the work tree with the point it branched off --base. If any changed file matches the path
+filters, the version must be newer than the version at --base following the version scheme.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Base, "base", "", "", "The ref the changes are merged into, such as origin/main.")
+	cmd.Flags().StringVarP(&options.ConfigDirectory, "config", "c", "Config", "Folder where the ini file holding the version lives.")
+	cmd.Flags().StringVarP(&options.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini file holding the version.")
+	cmd.Flags().StringVarP(&options.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the project or a parent folder.")
+	cmd.Flags().StringVarP(&options.Scheme, "scheme", "", "", "Version scheme, defaults to the scheme of the config file, else four-part when the current version has four numbers and "+scheme.Default+" otherwise.")
+	cmd.Flags().StringSliceVarP(&options.Paths, "path", "", nil, "Files that need a new version when they change, * matches across folders. Defaults to the config file or "+strings.Join(pathfilter.DefaultInclude, ", ")+".")
+	cmd.Flags().StringSliceVarP(&options.Ignore, "ignore", "", nil, "Files that never need a new version, defaults to the config file.")
+	_ = cmd.MarkFlagRequired("base")
+	return cmd
+}
+
+// Run implements the command
+func (o *RequireBumpOptions) Run() error {
+	projectDir := filepath.Dir(o.ConfigDirectory)
+	cfg, err := config.FindAndLoad(projectDir, o.SettingsFile)
+	if err != nil {
+		return err
+	}
+	filter := &pathfilter.Filter{Include: cfg.RequireBump.Paths, Exclude: cfg.RequireBump.Ignore}
+	if len(o.Paths) > 0 {
+		filter.Include = o.Paths
+	}
+	if len(o.Ignore) > 0 {
+		filter.Exclude = o.Ignore
+	}
+	changed, err := gitutil.ChangedFiles(projectDir, o.Base)
+	if err != nil {
+		return err
+	}
+	files := filter.Files(changed)
+	if len(files) == 0 {
+		log.Logger().Infof("None of the %d changed files since %s need a new version", len(changed), o.Base)
+		return nil
+	}
+	log.Logger().Debugf("Files needing a new version: %s", strings.Join(files, ", "))
+
+	path, err := ueini.FindKey(o.ConfigDirectory, o.Section, o.Key)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errors.Errorf("could not find a current %s in any *.ini file in %s", o.Key, o.ConfigDirectory)
+	}
+	current, err := o.version(path)
+	if err != nil {
+		return err
+	}
+	base, err := o.baseVersion(projectDir, path)
+	if err != nil || base == "" {
+		return err
+	}
+	name := o.Scheme
+	if name == "" {
+		name = cfg.Scheme
+	}
+	s, err := scheme.Resolve(name, current)
+	if err != nil {
+		return err
+	}
+
+	currentVersion, err := s.Parse(current)
+	if err != nil {
+		return errors.Wrapf(err, "checking %s in %s", o.Key, path)
+	}
+	baseVersion, err := s.Parse(base)
+	if err != nil {
+		log.Logger().Warnf("Only checking %s changed, %s at %s is not a %s version", o.Key, base, o.Base, s.Name())
+		if current != base {
+			return nil
+		}
+	} else if s.Compare(currentVersion, baseVersion) > 0 {
+		log.Logger().Infof("%s was bumped from %s to %s", o.Key, base, utils.ColorInfo(current))
+		return nil
+	}
+	return errors.Errorf("%s in %s is %s, it must be newer than %s at %s because game files changed:\n%s",
+		o.Key, path, current, base, o.Base, listFiles(files))
+}
+
+// version reads the key from the ini file in the work tree
+func (o *RequireBumpOptions) version(path string) (string, error) {
+	cfg, err := ueini.Load(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "loading %s", path)
+	}
+	return cfg.Section(o.Section).Key(o.Key).String(), nil
+}
+
+// baseVersion reads the key from the ini file at the base ref, it is empty if the base has none
+func (o *RequireBumpOptions) baseVersion(projectDir string, path string) (string, error) {
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", path)
+	}
+	content, ok, err := gitutil.Show(projectDir, o.Base, rel)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		log.Logger().Infof("%s does not exist at %s, nothing to compare with", rel, o.Base)
+		return "", nil
+	}
+	cfg, err := ueini.Load(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "loading %s at %s", rel, o.Base)
+	}
+	base := cfg.Section(o.Section).Key(o.Key).String()
+	if base == "" {
+		log.Logger().Infof("%s has no %s at %s, nothing to compare with", rel, o.Key, o.Base)
+	}
+	return base, nil
+}
+
+// listFiles indents the files one per line, summing up those past maxListedFiles
+func listFiles(files []string) string {
+	var lines []string
+	for i, file := range files {
+		if i == maxListedFiles {
+			lines = append(lines, fmt.Sprintf("  and %d more", len(files)-maxListedFiles))
+			break
+		}
+		lines = append(lines, "  "+file)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,642 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/buildnumber"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/changelist"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/filetarget"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitobjects"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/pathfilter"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/settings"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/steam"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/texttarget"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ProjectVersionKey is the key the version is written to unless --key says otherwise
+	ProjectVersionKey = "ProjectVersion"
+)
+
+// VersionUpdaterOptions the options for setting the version of the project
+type VersionUpdaterOptions struct {
+	*common.CommonOptions
+	IsProject         bool
+	IsPlugin          bool
+	ConfigDirectory   string
+	Section           string
+	Key               string
+	SettingsFile      string
+	Scheme            string
+	Extract           bool
+	ExtractPrerelease bool
+	ReleasePrefixes   []string
+	PluginFile        string
+	PluginVersion     int
+	IsEngine          bool
+	EngineDir         string
+	Compatible        uint64
+	Changelist        uint64
+	ChangelistFrom    string
+	ChangelistEnv     string
+	Branch            string
+	AllowDowngrade    bool
+	DryRun            bool
+	Ref               string
+	GitDir            string
+	Message           string
+
+	// snapshot holds the files of --ref while the version is set without a work tree
+	snapshot *gitobjects.Snapshot
+}
+
+// versionTarget is where the version lives and how it is written
+type versionTarget struct {
+	path       string
+	projectDir string
+	config     *config.Config
+	// schemeName is the scheme given by the flag or config file, empty when it is inferred
+	schemeName string
+	scheme     scheme.Scheme
+	normalizer *scheme.Normalizer
+	current    string
+	// gitDir and rev are the repository and commit git values are read from, the project and HEAD
+	// unless --ref is set
+	gitDir string
+	rev    string
+	// buildNumber is the next build number once the version is being set, empty without a counter
+	buildNumber string
+	// plugin is set when the version of a plugin is updated instead of the project's
+	plugin *descriptor.Plugin
+	// engine is set when the version of an engine is updated, path is then its Build.version
+	engine *engines.BuildVersion
+}
+
+func (o *VersionUpdaterOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.IsProject, "project", "p", true, "Is the version being updated a project?")
+	cmd.Flags().BoolVarP(&o.IsPlugin, "plugin", "l", false, "Is the version being updated a plugin? Sets VersionName and increases Version of the .uplugin.")
+	cmd.Flags().StringVarP(&o.PluginFile, "uplugin", "", "", "Path to the .uplugin file, defaults to the only one next to the Config folder.")
+	cmd.Flags().IntVarP(&o.PluginVersion, "plugin-version", "", 0, "Version number to write to the .uplugin, defaults to the current one plus one.")
+	cmd.Flags().BoolVarP(&o.IsEngine, "engine", "", false, "Is the version being updated an engine? Sets Build.version and the version macros of Version.h.")
+	cmd.Flags().StringVarP(&o.EngineDir, "engine-dir", "", ".", "Root of the engine, the folder holding Engine.")
+	cmd.Flags().Uint64VarP(&o.Compatible, "compatible-changelist", "", 0, "CompatibleChangelist to write to Build.version, defaults to the changelist for a new major or minor version and else the current one.")
+	cmd.Flags().StringVarP(&o.ConfigDirectory, "config", "c", "Config", "Folder where the ini file to be updated live.")
+	cmd.Flags().StringVarP(&o.Section, "section", "", ueini.GeneralProjectSettings, "Section of the ini file holding the version.")
+	cmd.Flags().StringVarP(&o.Key, "key", "", ProjectVersionKey, "Key holding the version.")
+	cmd.Flags().StringVarP(&o.SettingsFile, "settings", "", "", "The tool's config file, defaults to .uvu/config.yaml in the project or a parent folder.")
+	cmd.Flags().StringVarP(&o.Scheme, "scheme", "", "", "Version scheme, one of "+strings.Join(scheme.Names(), ", ")+". Defaults to the scheme of the config file, else four-part when the current and the new version have four numbers and "+scheme.Default+" otherwise.")
+	cmd.Flags().BoolVarP(&o.Extract, "extract", "", false, "Look for a version inside the input when it doesn't match the scheme once ref, release and v prefixes are stripped, instead of failing. Only its numbers are used.")
+	cmd.Flags().BoolVarP(&o.ExtractPrerelease, "extract-prerelease", "", false, "Keep the prerelease after the numbers of a version found with --extract, such as -rc.1.")
+	cmd.Flags().StringSliceVarP(&o.ReleasePrefixes, "release-prefix", "", nil, "Named release prefix to strip from the version such as release-, defaults to the config file or "+strings.Join(scheme.DefaultReleasePrefixes, ", ")+".")
+	cmd.Flags().Uint64VarP(&o.Changelist, "changelist", "", 0, "Perforce changelist for the changelist scheme, instead of counting git commits.")
+	cmd.Flags().StringVarP(&o.ChangelistFrom, "changelist-source", "", "", "Where the changelist comes from when --changelist isn't given, one of "+strings.Join(changelist.Sources, ", ")+". Defaults to the config file or git.")
+	cmd.Flags().StringVarP(&o.ChangelistEnv, "changelist-env", "", "", "Environment variable holding the changelist for the env source, defaults to the config file or "+changelist.DefaultEnv+".")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "", "", "Branch written after the changelist such as //Game/Main, defaults to the config file.")
+	cmd.Flags().BoolVarP(&o.AllowDowngrade, "allow-downgrade", "", false, "Write versions that are older than or the same as the current ones.")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "Print the diff without writing anything.")
+	cmd.Flags().StringVarP(&o.Ref, "ref", "", "", "Branch to commit the change to with git plumbing instead of editing the work tree, for bare repositories.")
+	cmd.Flags().StringVarP(&o.GitDir, "git-dir", "", ".", "The repository --ref is in, such as a bare mirror.")
+	cmd.Flags().StringVarP(&o.Message, "message", "m", "", "Commit message with --ref, defaults to \"Set <key> to <version>\".")
+}
+
+// inRef runs fn on a snapshot of the files of --ref, which fn edits as if they were the work tree. The
+// changes are committed by setVersion. Without --ref fn runs on the work tree.
+func (o *VersionUpdaterOptions) inRef(fn func() error) error {
+	if o.Ref == "" {
+		return fn()
+	}
+	if o.IsPlugin || o.IsEngine {
+		return errors.New("--plugin and --engine can't be combined with --ref")
+	}
+	gitDir, err := filepath.Abs(o.GitDir)
+	if err != nil {
+		return errors.Wrapf(err, "resolving %s", o.GitDir)
+	}
+	if o.SettingsFile != "" {
+		if o.SettingsFile, err = filepath.Abs(o.SettingsFile); err != nil {
+			return errors.Wrapf(err, "resolving %s", o.SettingsFile)
+		}
+	}
+	configDir := filepath.ToSlash(filepath.Clean(o.ConfigDirectory))
+	if filepath.IsAbs(configDir) || strings.HasPrefix(configDir, "../") {
+		return errors.Errorf("--config must be a folder inside the repository with --ref, not %s", o.ConfigDirectory)
+	}
+	projectDir := path.Dir(configDir)
+	snapshot, err := gitobjects.Extract(gitDir, o.Ref, func(name string) bool {
+		return strings.HasPrefix(name, configDir+"/") ||
+			strings.HasPrefix("/"+name, "/"+config.Dir+"/") || strings.Contains(name, "/"+config.Dir+"/") ||
+			(path.Dir(name) == projectDir && path.Ext(name) == descriptor.ProjectExtension)
+	})
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+	log.Logger().Debugf("Extracted %s at %s to %s", snapshot.Ref, snapshot.Parent, snapshot.Dir)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(err, "finding the current folder")
+	}
+	if err := os.Chdir(snapshot.Dir); err != nil {
+		return errors.Wrapf(err, "changing to %s", snapshot.Dir)
+	}
+	defer func() { _ = os.Chdir(cwd) }()
+	if err := addTargets(snapshot, projectDir, o.SettingsFile); err != nil {
+		return err
+	}
+	o.snapshot = snapshot
+	defer func() { o.snapshot = nil }()
+	return fn()
+}
+
+// addTargets extracts the files of the text, file and steam targets, which are only known once the config
+// file has been extracted
+func addTargets(snapshot *gitobjects.Snapshot, projectDir string, settingsFile string) error {
+	cfg, err := config.FindAndLoad(projectDir, settingsFile)
+	if err != nil || (len(cfg.TextTargets)+len(cfg.FileTargets) == 0 && cfg.Steam.Files == "") {
+		return err
+	}
+	root, err := filepath.Rel(snapshot.Dir, cfg.Root(filepath.Join(snapshot.Dir, projectDir)))
+	if err != nil {
+		return errors.Wrapf(err, "resolving the folder holding %s", config.Dir)
+	}
+	filter := pathfilter.Filter{}
+	for _, t := range cfg.TextTargets {
+		filter.Include = append(filter.Include, t.Files)
+	}
+	for _, t := range cfg.FileTargets {
+		filter.Include = append(filter.Include, t.File)
+	}
+	if cfg.Steam.Files != "" {
+		filter.Include = append(filter.Include, cfg.Steam.Files)
+	}
+	return snapshot.Add(func(name string) bool {
+		rel, err := filepath.Rel(root, filepath.FromSlash(name))
+		return err == nil && !strings.HasPrefix(filepath.ToSlash(rel), "../") && filter.Match(rel)
+	})
+}
+
+// Run sets the version and renders the configured templates, writing every file in one go
+func (o *VersionUpdaterOptions) Run() error {
+	return o.inRef(o.run)
+}
+
+func (o *VersionUpdaterOptions) run() error {
+	target, err := o.target()
+	if err != nil {
+		return err
+	}
+	var version scheme.Version
+	target.scheme, version, err = target.normalizer.Resolve(target.schemeName, target.current, o.Args[0])
+	if err != nil {
+		return err
+	}
+	return o.setVersion(target, version)
+}
+
+// target finds the file holding the current version and the scheme it follows
+func (o *VersionUpdaterOptions) target() (*versionTarget, error) {
+	answer := &versionTarget{projectDir: filepath.Dir(o.ConfigDirectory)}
+	answer.gitDir = answer.projectDir
+	if o.snapshot != nil {
+		answer.gitDir, answer.rev = o.snapshot.GitDir, o.snapshot.Parent
+	}
+	var err error
+	switch {
+	case o.IsEngine:
+		answer.projectDir, answer.gitDir = o.EngineDir, o.EngineDir
+		err = o.findEngine(answer)
+	case o.IsPlugin:
+		err = o.findPlugin(answer)
+	default:
+		err = o.findIni(answer)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Debugf("Found version %s in %s", answer.current, answer.path)
+
+	answer.config, err = config.FindAndLoad(answer.projectDir, o.SettingsFile)
+	if err != nil {
+		return nil, err
+	}
+	name := o.Scheme
+	if name == "" {
+		name = answer.config.Scheme
+	}
+	if o.IsEngine {
+		// engine versions always have the numbers, changelist and branch of FEngineVersion
+		if o.Scheme != "" && o.Scheme != (scheme.Changelist{}).Name() {
+			return nil, errors.Errorf("--engine uses the %s scheme, not %s", (scheme.Changelist{}).Name(), o.Scheme)
+		}
+		name = (scheme.Changelist{}).Name()
+	}
+	answer.schemeName = name
+	answer.scheme, err = scheme.Resolve(name, answer.current)
+	if err != nil {
+		return nil, err
+	}
+	answer.normalizer = newNormalizer(answer.config)
+	answer.normalizer.Extract = answer.normalizer.Extract || o.Extract
+	answer.normalizer.ExtractPrerelease = answer.normalizer.ExtractPrerelease || o.ExtractPrerelease
+	if len(o.ReleasePrefixes) > 0 {
+		answer.normalizer.ReleasePrefixes = o.ReleasePrefixes
+	}
+	return answer, nil
+}
+
+func (o *VersionUpdaterOptions) findIni(target *versionTarget) error {
+	path, err := ueini.FindKey(o.ConfigDirectory, o.Section, o.Key)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return errors.Errorf("could not find a current %s in any *.ini file in %s, please add a current version", o.Key, o.ConfigDirectory)
+	}
+	cfg, err := ueini.Load(path)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", path)
+	}
+	target.path = path
+	target.current = cfg.Section(o.Section).Key(o.Key).String()
+	return nil
+}
+
+func (o *VersionUpdaterOptions) findPlugin(target *versionTarget) error {
+	path := o.PluginFile
+	if path == "" {
+		var err error
+		if path, err = descriptor.FindPlugin(target.projectDir); err != nil {
+			return err
+		}
+	}
+	plugin, err := descriptor.LoadPlugin(path)
+	if err != nil {
+		return err
+	}
+	target.path = path
+	target.plugin = plugin
+	target.current = plugin.VersionName
+	return nil
+}
+
+// findEngine reads Build.version, Version.h has to exist and is only compared with it
+func (o *VersionUpdaterOptions) findEngine(target *versionTarget) error {
+	path := engines.BuildVersionPath(o.EngineDir)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s, --engine-dir must be the folder holding Engine", path)
+	}
+	engine, err := engines.ParseBuildVersion(data)
+	if err != nil {
+		return errors.Wrapf(err, "in %s", path)
+	}
+	header := engines.VersionHeaderPath(o.EngineDir)
+	data, err = ioutil.ReadFile(header)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", header)
+	}
+	macros, err := engines.ParseVersionHeader(data)
+	if err != nil {
+		return errors.Wrapf(err, "in %s", header)
+	}
+	if numbers := [3]int{engine.MajorVersion, engine.MinorVersion, engine.PatchVersion}; macros != numbers {
+		log.Logger().Warnf("%s has %d.%d.%d but %s has %s, both are set to the new version", header, macros[0], macros[1], macros[2], path, engine)
+	}
+	if err := engine.Check(); err != nil {
+		log.Logger().Warnf("%s: %s", path, err)
+	}
+	current := &scheme.ChangelistVersion{
+		Major:      uint64(engine.MajorVersion),
+		Minor:      uint64(engine.MinorVersion),
+		Patch:      uint64(engine.PatchVersion),
+		Changelist: uint64(engine.Changelist),
+		Branch:     engine.BranchName,
+	}
+	target.path = path
+	target.engine = engine
+	target.current = current.String()
+	return nil
+}
+
+func (o *VersionUpdaterOptions) setVersion(target *versionTarget, version scheme.Version) error {
+	if v, ok := version.(*scheme.ChangelistVersion); ok {
+		if err := o.changelistSource(target).Fill(target.gitDir, v); err != nil {
+			return err
+		}
+	}
+	plan := edit.NewPlan()
+	unlock, err := o.nextBuildNumber(plan, target)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	g := &guard.Guard{AllowDowngrade: o.AllowDowngrade}
+	switch {
+	case target.engine != nil:
+		err = o.planEngine(plan, target, version, g)
+	case target.plugin != nil:
+		err = o.planPlugin(plan, target, version, g)
+	default:
+		err = o.planIni(plan, target, version, g)
+	}
+	if err != nil {
+		return err
+	}
+	if err := o.planTargets(plan, target, version); err != nil {
+		return err
+	}
+	if err := applyPlan(o.Out, plan, o.DryRun); err != nil || o.DryRun || o.snapshot == nil {
+		return err
+	}
+	return o.commit(version)
+}
+
+// commit records the snapshot's changes on --ref, including the build number counter
+func (o *VersionUpdaterOptions) commit(version scheme.Version) error {
+	changes, err := o.snapshot.Changes()
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+	message := o.Message
+	if message == "" {
+		message = fmt.Sprintf("Set %s to %s", o.Key, version)
+	}
+	commit, err := o.snapshot.Commit(message)
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("Committed %s to %s", utils.ColorInfo(commit), o.snapshot.Ref)
+	return nil
+}
+
+// nextBuildNumber plans incrementing the build number counter if it is in use, so the number is only
+// used up when the version is written. The counter stays locked until the returned unlock is called.
+func (o *VersionUpdaterOptions) nextBuildNumber(plan *edit.Plan, target *versionTarget) (func(), error) {
+	counter, ok := buildnumber.FromConfig(target.config, relativeRoot(target.config, target.projectDir))
+	if !ok {
+		return func() {}, nil
+	}
+	n, unlock, err := counter.Reserve(plan)
+	if err != nil {
+		return nil, err
+	}
+	target.buildNumber = strconv.FormatUint(n, 10)
+	log.Logger().Infof("Build number %s", utils.ColorInfo(target.buildNumber))
+	return unlock, nil
+}
+
+// changelistSource combines the changelist flags with the config file, the flags win
+func (o *VersionUpdaterOptions) changelistSource(target *versionTarget) *changelist.Source {
+	answer := &changelist.Source{
+		Changelist: o.Changelist,
+		Kind:       target.config.Changelist.Source,
+		Env:        target.config.Changelist.Env,
+		Branch:     target.config.Changelist.Branch,
+		Rev:        target.rev,
+	}
+	if o.ChangelistFrom != "" {
+		answer.Kind = o.ChangelistFrom
+	}
+	if o.ChangelistEnv != "" {
+		answer.Env = o.ChangelistEnv
+	}
+	if o.Branch != "" {
+		answer.Branch = o.Branch
+	}
+	return answer
+}
+
+func (o *VersionUpdaterOptions) planIni(plan *edit.Plan, target *versionTarget, version scheme.Version, g *guard.Guard) error {
+	if err := g.Version(target.scheme, o.Key, target.path, target.current, version); err != nil {
+		return err
+	}
+	log.Logger().Infof("Setting %s to %s", o.Key, utils.ColorInfo(version.String()))
+	err := plan.Edit(target.path, o.Key, func(content []byte) ([]byte, error) {
+		return ueini.SetKey(content, o.Section, o.Key, version.String())
+	})
+	if err != nil {
+		return err
+	}
+	return o.planTemplates(plan, target, version, g)
+}
+
+// planEngine writes the version to Build.version and Version.h. A new major or minor version is only
+// compatible with itself, a patch keeps the CompatibleChangelist of the release it fixes.
+func (o *VersionUpdaterOptions) planEngine(plan *edit.Plan, target *versionTarget, version scheme.Version, g *guard.Guard) error {
+	v, ok := version.(*scheme.ChangelistVersion)
+	if !ok {
+		return errors.Errorf("%s is not an engine version", version)
+	}
+	current := target.engine
+	if v.Branch == "" {
+		v.Branch = current.BranchName
+	}
+	if err := g.Version(target.scheme, "the engine version", target.path, target.current, v); err != nil {
+		return err
+	}
+	updated := *current
+	updated.MajorVersion, updated.MinorVersion, updated.PatchVersion = int(v.Major), int(v.Minor), int(v.Patch)
+	updated.Changelist, updated.BranchName = int(v.Changelist), v.Branch
+	switch {
+	case o.Compatible > 0:
+		updated.CompatibleChangelist = int(o.Compatible)
+	case updated.MajorVersion != current.MajorVersion || updated.MinorVersion != current.MinorVersion:
+		updated.CompatibleChangelist = updated.Changelist
+	}
+	if err := updated.Check(); err != nil {
+		return errors.Wrapf(err, "refusing to write %s", target.path)
+	}
+	log.Logger().Infof("Setting the engine version to %s compatible with %s", utils.ColorInfo(v.String()), utils.ColorInfo(strconv.Itoa(updated.CompatibleChangelist)))
+	err := plan.Edit(target.path, "engine version", func(content []byte) ([]byte, error) {
+		return engines.SetBuildVersion(content, &updated)
+	})
+	if err != nil {
+		return err
+	}
+	header := engines.VersionHeaderPath(o.EngineDir)
+	return plan.Edit(header, "engine version", func(content []byte) ([]byte, error) {
+		return engines.SetVersionHeader(content, [3]int{updated.MajorVersion, updated.MinorVersion, updated.PatchVersion})
+	})
+}
+
+// planPlugin sets VersionName to the version and increases the Version number, which the marketplace
+// and the engine use to order plugin releases
+func (o *VersionUpdaterOptions) planPlugin(plan *edit.Plan, target *versionTarget, version scheme.Version, g *guard.Guard) error {
+	plugin := target.plugin
+	number := plugin.Version + 1
+	if o.PluginVersion > 0 {
+		number = o.PluginVersion
+	}
+	if err := g.Version(target.scheme, descriptor.VersionNameKey, target.path, plugin.VersionName, version); err != nil {
+		return err
+	}
+	if err := g.Integer(descriptor.VersionKey, target.path, strconv.Itoa(plugin.Version), strconv.Itoa(number)); err != nil {
+		return err
+	}
+	log.Logger().Infof("Setting %s %s to %s and %s to %s", plugin.Name(), descriptor.VersionNameKey, utils.ColorInfo(version.String()), descriptor.VersionKey, utils.ColorInfo(strconv.Itoa(number)))
+	return plan.Edit(target.path, "version", func(content []byte) ([]byte, error) {
+		content, err := descriptor.SetField(content, descriptor.VersionNameKey, version.String())
+		if err != nil {
+			return nil, err
+		}
+		return descriptor.SetField(content, descriptor.VersionKey, number)
+	})
+}
+
+// templateData collects the values templates and targets can use
+func (o *VersionUpdaterOptions) templateData(target *versionTarget, version scheme.Version) (stamp.Data, error) {
+	projectSettings := &settings.GeneralProjectSettings{}
+	if target.plugin == nil && target.engine == nil {
+		settingsFile, err := settings.Find(o.ConfigDirectory)
+		if err != nil {
+			return stamp.Data{}, err
+		}
+		if projectSettings, err = settings.Load(settingsFile); err != nil {
+			return stamp.Data{}, err
+		}
+	}
+	data := stamp.NewData(target.projectDir, version.String(), projectSettings)
+	data.BuildNumber = target.buildNumber
+	if v, ok := version.(*scheme.SemVerVersion); ok {
+		data.Prerelease = strings.Join(v.Prerelease, ".")
+	}
+	if o.snapshot != nil {
+		data.SetGit(o.snapshot.GitDir, o.snapshot.Ref)
+	}
+	return data, nil
+}
+
+func (o *VersionUpdaterOptions) planTemplates(plan *edit.Plan, target *versionTarget, version scheme.Version, g *guard.Guard) error {
+	if len(target.config.Templates) == 0 {
+		return nil
+	}
+	data, err := o.templateData(target, version)
+	if err != nil {
+		return err
+	}
+	return stamp.Plan(plan, o.ConfigDirectory, target.path, target.config.Templates, data, g)
+}
+
+// planTargets writes the version to the text, file and steam targets of the config, for projects and plugins
+func (o *VersionUpdaterOptions) planTargets(plan *edit.Plan, target *versionTarget, version scheme.Version) error {
+	cfg := target.config
+	if len(cfg.TextTargets) == 0 && len(cfg.FileTargets) == 0 && cfg.Steam.Files == "" {
+		return nil
+	}
+	data, err := o.templateData(target, version)
+	if err != nil {
+		return err
+	}
+	root := relativeRoot(cfg, target.projectDir)
+	if err := texttarget.Plan(plan, root, cfg.TextTargets, data); err != nil {
+		return err
+	}
+	if err := filetarget.Plan(plan, root, cfg.FileTargets, data); err != nil {
+		return err
+	}
+	return steam.Plan(plan, root, cfg.Steam, data)
+}
+
+// newNormalizer returns the normalizer of the config file
+func newNormalizer(cfg *config.Config) *scheme.Normalizer {
+	return &scheme.Normalizer{
+		ReleasePrefixes:   cfg.Normalize.ReleasePrefixes,
+		Extract:           cfg.Normalize.Extract,
+		ExtractPrerelease: cfg.Normalize.ExtractPrerelease,
+	}
+}
+
+// checkVersion normalizes raw in the scheme of the config file, or the one inferred from current, the way
+// setting the version does and returns it once g allows writing it over current. settings set and ini set
+// use it for ProjectVersion.
+func checkVersion(cfg *config.Config, g *guard.Guard, location string, current string, raw string) (scheme.Version, error) {
+	s, version, err := newNormalizer(cfg).Resolve(cfg.Scheme, current, raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Version(s, ProjectVersionKey, location, current, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// relativeRoot returns the folder holding .uvu relative to the current folder when the project folder
+// is, so diffs show short paths
+func relativeRoot(cfg *config.Config, projectDir string) string {
+	root := cfg.Root(projectDir)
+	abs, err := filepath.Abs(projectDir)
+	if err != nil || filepath.IsAbs(projectDir) {
+		return root
+	}
+	rel, err := filepath.Rel(abs, root)
+	if err != nil {
+		return root
+	}
+	return filepath.Join(projectDir, rel)
+}
+
+// NewCmdBump creates the command increasing the current version
+func NewCmdBump(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &VersionUpdaterOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "bump [part]",
+		Short: "Increases the current version following the version scheme",
+		Long:  "Increases part of the current version and resets the parts after it, the last part by default. semver and changelist have major, minor and patch, four-part has major, minor, build and revision, calver and integer have build.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Bump()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	return cmd
+}
+
+// Bump implements the bump command
+func (o *VersionUpdaterOptions) Bump() error {
+	return o.inRef(o.bump)
+}
+
+func (o *VersionUpdaterOptions) bump() error {
+	target, err := o.target()
+	if err != nil {
+		return err
+	}
+	current, err := target.scheme.Parse(target.current)
+	if err != nil {
+		return errors.Wrapf(err, "the current %s in %s can't be bumped", o.Key, target.path)
+	}
+	part := ""
+	if len(o.Args) > 0 {
+		part = o.Args[0]
+	}
+	version, err := target.scheme.Bump(current, part)
+	if err != nil {
+		return err
+	}
+	return o.setVersion(target, version)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionUpdaterOptions_RunRefTwice(t *testing.T) {
+	work := t.TempDir()
+	run := func(dir string, args ...string) string {
+		out, err := gitutil.Run(dir, args...)
+		assert.NoError(t, err)
+		return out
+	}
+	run(work, "init", "-q")
+	run(work, "checkout", "-q", "-b", "main")
+	run(work, "config", "user.email", "test@example.com")
+	run(work, "config", "user.name", "Test")
+	run(work, "config", "commit.gpgsign", "false")
+	for name, content := range map[string]string{
+		"Config/DefaultGame.ini": "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.0.0\n",
+		"Game.uproject":          "{}\n",
+		".uvu/config.yaml":       "build-number:\n  enabled: true\n",
+	} {
+		path := filepath.Join(work, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	run(work, "add", "-A")
+	run(work, "commit", "-q", "-m", "initial")
+	bare := filepath.Join(t.TempDir(), "game.git")
+	run(work, "clone", "-q", "--bare", work, bare)
+	run(bare, "config", "user.email", "bot@example.com")
+	run(bare, "config", "user.name", "Bot")
+
+	for _, version := range []string{"1.1.0", "1.2.0"} {
+		o := &VersionUpdaterOptions{CommonOptions: &common.CommonOptions{Out: os.Stdout}}
+		cmd := &cobra.Command{}
+		o.addFlags(cmd)
+		assert.NoError(t, cmd.ParseFlags([]string{"--ref", "main", "--git-dir", bare}))
+		o.Args = []string{version}
+		assert.NoError(t, o.Run())
+	}
+
+	assert.Equal(t, ".uvu/build-number\n.uvu/config.yaml\nConfig/DefaultGame.ini\nGame.uproject", run(bare, "ls-tree", "-r", "--name-only", "main"))
+	assert.Equal(t, "2", run(bare, "show", "main:.uvu/build-number"))
+	assert.Contains(t, run(bare, "show", "main:Config/DefaultGame.ini"), "ProjectVersion=1.2.0")
+}
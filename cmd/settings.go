@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// SettingsOptions the options for the settings get and set commands
+type SettingsOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	ConfigDir      string
+	SettingsFile   string
+	DryRun         bool
+	AllowDowngrade bool
+}
+
+func (o *SettingsOptions) addFlags(cmd *cobra.Command) {
+	o.ProjectFlags.addFlags(cmd)
+	cmd.Flags().StringVarP(&o.ConfigDir, "config", "c", "", "Folder holding the project's ini files, defaults to the Config folder next to the .uproject.")
+}
+
+// NewCmdSettings creates the command grouping the GeneralProjectSettings commands
+func NewCmdSettings(commonOpts *common.CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Reads and writes any field of GeneralProjectSettings",
+	}
+	cmd.AddCommand(NewCmdSettingsGet(commonOpts))
+	cmd.AddCommand(NewCmdSettingsSet(commonOpts))
+	return cmd
+}
+
+// NewCmdSettingsGet creates the settings get command
+func NewCmdSettingsGet(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &SettingsOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "get [field]",
+		Short: "Prints a field of GeneralProjectSettings, or every field that is set",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Get()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	return cmd
+}
+
+// NewCmdSettingsSet creates the settings set command
+func NewCmdSettingsSet(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &SettingsOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "set <field> <value> | set <field>=<value>...",
+		Short: "Writes fields of GeneralProjectSettings",
+		Long:  "Validates every value against the type of its field and writes them all in one go, if any value is invalid nothing is written. Booleans accept true/false, text fields keep the namespace and key of localized text.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Set()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Print the diff without writing anything.")
+	cmd.Flags().BoolVarP(&options.AllowDowngrade, "allow-downgrade", "", false, "Write a ProjectVersion older than or the same as the current one.")
+	cmd.Flags().StringVarP(&options.SettingsFile, "settings", "", "", "The tool's config file with the scheme of ProjectVersion, defaults to .uvu/config.yaml in the project or a parent folder.")
+	return cmd
+}
+
+// Get implements the settings get command
+func (o *SettingsOptions) Get() error {
+	path, err := settings.Find(o.configDir(o.ConfigDir))
+	if err != nil {
+		return err
+	}
+	projectSettings, err := settings.Load(path)
+	if err != nil {
+		return err
+	}
+	if len(o.Args) == 1 {
+		field, err := settings.LookupField(o.Args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, field.Get(projectSettings))
+		return nil
+	}
+	empty := &settings.GeneralProjectSettings{}
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tTYPE\tVALUE")
+	for _, field := range settings.Fields() {
+		if field.Get(projectSettings) == field.Get(empty) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", field.Name, field.Kind, field.Get(projectSettings))
+	}
+	return w.Flush()
+}
+
+// Set implements the settings set command
+func (o *SettingsOptions) Set() error {
+	assignments, err := settings.ParseAssignments(o.Args)
+	if err != nil {
+		return err
+	}
+	path, err := settings.Find(o.configDir(o.ConfigDir))
+	if err != nil {
+		return err
+	}
+	current, err := settings.Load(path)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for i, assignment := range assignments {
+		names = append(names, assignment.Field)
+		if strings.EqualFold(assignment.Field, ProjectVersionKey) {
+			if assignments[i].Value, err = o.validateVersion(path, assignment.Value, current.ProjectVersion); err != nil {
+				return err
+			}
+		}
+	}
+	plan := edit.NewPlan()
+	err = plan.Edit(path, strings.Join(names, ", "), func(content []byte) ([]byte, error) {
+		return settings.Apply(content, assignments)
+	})
+	if err != nil {
+		return err
+	}
+	return applyPlan(o.Out, plan, o.DryRun)
+}
+
+// validateVersion checks ProjectVersion the same way setting the version does
+func (o *SettingsOptions) validateVersion(path string, version string, current string) (string, error) {
+	cfg, err := config.FindAndLoad(filepath.Dir(o.configDir(o.ConfigDir)), o.SettingsFile)
+	if err != nil {
+		return "", err
+	}
+	parsed, err := checkVersion(cfg, &guard.Guard{AllowDowngrade: o.AllowDowngrade}, path, current, version)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
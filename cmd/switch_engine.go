@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// SwitchEngineOptions the options for the switch-engine command
+type SwitchEngineOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	SkipValidation bool
+}
+
+// NewCmdSwitchEngine creates the command that changes which engine a project is associated with
+func NewCmdSwitchEngine(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &SwitchEngineOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "switch-engine <version|guid>",
+		Short: "Changes the EngineAssociation of the .uproject",
+		Long:  "Changes the EngineAssociation of the .uproject to an engine version such as 5.3 or to a source build GUID, checking the engine is registered on this machine",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().BoolVarP(&options.SkipValidation, "skip-validation", "", false, "Don't check the engine is registered on this machine.")
+	return cmd
+}
+
+// Run implements the command
+func (o *SwitchEngineOptions) Run() error {
+	identifier := o.Args[0]
+	if err := engines.ValidateIdentifier(identifier); err != nil {
+		return err
+	}
+
+	if !o.SkipValidation {
+		installations, err := engines.Installations()
+		if err != nil {
+			return err
+		}
+		installation, ok := engines.Find(installations, identifier)
+		if !ok {
+			return errors.Errorf("engine %s is not registered on this machine, registered engines: [%s], use --skip-validation to set it anyway",
+				identifier, strings.Join(identifiers(installations), ", "))
+		}
+		identifier = installation.Identifier
+		log.Logger().Debugf("Found engine %s at %s (%s)", identifier, installation.Path, installation.Source)
+	}
+
+	project, err := o.loadProject()
+	if err != nil {
+		return err
+	}
+	path := project.Path
+	if project.EngineAssociation == identifier {
+		log.Logger().Infof("%s already uses engine %s", path, utils.ColorInfo(identifier))
+		return nil
+	}
+	content, err := descriptor.SetField(project.Content, descriptor.EngineAssociationKey, identifier)
+	if err != nil {
+		return errors.Wrapf(err, "updating %s", path)
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	log.Logger().Infof("Switched %s from engine %s to %s", path, utils.ColorInfo(project.EngineAssociation), utils.ColorInfo(identifier))
+	return nil
+}
+
+func identifiers(installations []engines.Installation) []string {
+	var answer []string
+	for _, installation := range installations {
+		answer = append(answer, installation.Identifier)
+	}
+	return answer
+}
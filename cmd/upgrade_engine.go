@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/upgrade"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeEngineOptions the options for the upgrade-engine command
+type UpgradeEngineOptions struct {
+	*common.CommonOptions
+	ProjectFlags
+	DryRun bool
+}
+
+// NewCmdUpgradeEngine creates the command that moves a project to another engine version
+func NewCmdUpgradeEngine(commonOpts *common.CommonOptions) *cobra.Command {
+	options := &UpgradeEngineOptions{
+		CommonOptions: commonOpts,
+	}
+	cmd := &cobra.Command{
+		Use:   "upgrade-engine <version|guid>",
+		Short: "Moves the project, its plugins and targets to another engine version",
+		Long:  "Updates EngineAssociation in the .uproject, EngineVersion in every first-party .uplugin and DefaultBuildSettings/IncludeOrderVersion in each *.Target.cs. The edits are shown as a diff and written together, or not at all.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			common.CheckErr(err)
+		},
+	}
+	options.addFlags(cmd)
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only show the diff of the planned edits.")
+	return cmd
+}
+
+// Run implements the command
+func (o *UpgradeEngineOptions) Run() error {
+	association := o.Args[0]
+	if err := engines.ValidateIdentifier(association); err != nil {
+		return err
+	}
+	project, err := o.loadProject()
+	if err != nil {
+		return err
+	}
+	engine, err := engines.Resolve(association, project.Dir())
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("Upgrading %s from engine %s to %s (%s)", project.Name(), utils.ColorInfo(project.EngineAssociation), utils.ColorInfo(association), engine)
+
+	plan, err := upgrade.PlanEngineUpgrade(project, association, engine)
+	if err != nil {
+		return err
+	}
+	return applyPlan(o.Out, plan, o.DryRun)
+}
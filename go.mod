@@ -35,6 +35,7 @@ require (
 	github.com/xordataexchange/crypt v0.0.3-0.20170626215501-b2862e3d0a77 // indirect
 	go.etcd.io/bbolt v1.3.2 // indirect
 	golang.org/x/crypto v0.4.0 // indirect
+	golang.org/x/sys v0.3.0
 	gopkg.in/AlecAivazis/survey.v1 v1.8.8
 	gopkg.in/ini.v1 v1.67.0
 	gopkg.in/resty.v1 v1.12.0 // indirect
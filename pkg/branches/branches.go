@@ -0,0 +1,166 @@
+package branches
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+)
+
+// Reader returns the version at a commit, empty if the commit has none
+type Reader func(commit string) (string, error)
+
+// Branch is the version a branch claims
+type Branch struct {
+	gitutil.Ref
+	Version string
+	// Problems are collisions with other branches and versions lower than the merge base's
+	Problems []string
+}
+
+// Matrix reads the version of every branch and compares them
+type Matrix struct {
+	Dir    string
+	Scheme scheme.Scheme
+	Read   Reader
+	// Base is the branch others are compared with for going backwards, no check when empty
+	Base string
+
+	versions map[string]string
+}
+
+// Build reads the version of each ref, flags the problems and sorts the branches by version, newest first
+func (m *Matrix) Build(refs []gitutil.Ref) ([]*Branch, error) {
+	m.versions = map[string]string{}
+	var answer []*Branch
+	for _, ref := range refs {
+		version, err := m.version(ref.Commit)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, &Branch{Ref: ref, Version: version})
+	}
+	if err := m.collisions(answer); err != nil {
+		return nil, err
+	}
+	if m.Base != "" {
+		for _, b := range answer {
+			if err := m.backwards(b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	m.sort(answer)
+	return answer, nil
+}
+
+func (m *Matrix) version(commit string) (string, error) {
+	if version, ok := m.versions[commit]; ok {
+		return version, nil
+	}
+	version, err := m.Read(commit)
+	if err != nil {
+		return "", err
+	}
+	m.versions[commit] = version
+	return version, nil
+}
+
+// collisions flags branches that set the same version independently. Two branches sharing a version
+// they both inherited from their merge base don't collide.
+func (m *Matrix) collisions(branches []*Branch) error {
+	for i, a := range branches {
+		for _, b := range branches[i+1:] {
+			if a.Version == "" || a.Commit == b.Commit || !m.equal(a.Version, b.Version) {
+				continue
+			}
+			mergeBase, err := gitutil.MergeBase(m.Dir, a.Commit, b.Commit)
+			if err != nil {
+				return err
+			}
+			if mergeBase != "" {
+				inherited, err := m.version(mergeBase)
+				if err != nil {
+					return err
+				}
+				if m.equal(inherited, a.Version) {
+					continue
+				}
+			}
+			a.Problems = append(a.Problems, "same version as "+b.Name)
+			b.Problems = append(b.Problems, "same version as "+a.Name)
+		}
+	}
+	return nil
+}
+
+// backwards flags a branch whose version is lower than the version where it branched off the base
+func (m *Matrix) backwards(b *Branch) error {
+	if b.Version == "" || b.Name == m.Base {
+		return nil
+	}
+	mergeBase, err := gitutil.MergeBase(m.Dir, b.Commit, m.Base)
+	if err != nil || mergeBase == "" {
+		return err
+	}
+	inherited, err := m.version(mergeBase)
+	if err != nil || inherited == "" {
+		return err
+	}
+	x, err := m.Scheme.Parse(b.Version)
+	if err != nil {
+		return nil
+	}
+	y, err := m.Scheme.Parse(inherited)
+	if err != nil {
+		return nil
+	}
+	if m.Scheme.Compare(x, y) < 0 {
+		b.Problems = append(b.Problems, fmt.Sprintf("older than %s where it branched off %s", inherited, m.Base))
+	}
+	return nil
+}
+
+// equal compares in the scheme, falling back to the text for versions that don't follow it
+func (m *Matrix) equal(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	x, errX := m.Scheme.Parse(a)
+	y, errY := m.Scheme.Parse(b)
+	return errX == nil && errY == nil && m.Scheme.Compare(x, y) == 0
+}
+
+// sort orders the newest version first, then versions that don't follow the scheme, then branches
+// without a version, each by name
+func (m *Matrix) sort(branches []*Branch) {
+	parsed := map[*Branch]scheme.Version{}
+	for _, b := range branches {
+		if v, err := m.Scheme.Parse(b.Version); err == nil {
+			parsed[b] = v
+		}
+	}
+	rank := func(b *Branch) int {
+		switch {
+		case parsed[b] != nil:
+			return 0
+		case b.Version != "":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(branches, func(i, j int) bool {
+		a, b := branches[i], branches[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if rank(a) == 0 {
+			if c := m.Scheme.Compare(parsed[a], parsed[b]); c != 0 {
+				return c > 0
+			}
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
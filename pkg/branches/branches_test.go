@@ -0,0 +1,71 @@
+package branches
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix_Build(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) {
+		_, err := gitutil.Run(dir, args...)
+		assert.NoError(t, err)
+	}
+	commit := func(version string, message string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version"), []byte(version+"\n"), 0644))
+		run("commit", "-q", "-am", message)
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	run("config", "commit.gpgsign", "false")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version"), []byte("1.0.0\n"), 0644))
+	run("add", "-A")
+	run("commit", "-q", "-m", "1.0.0")
+	for _, branch := range []struct{ name, version string }{
+		{"release-a", "1.1.0"},
+		{"release-b", "1.1.0"},
+		{"old", "0.9.0"},
+		{"feature", ""},
+	} {
+		run("checkout", "-q", "-b", branch.name, "main")
+		if branch.version != "" {
+			commit(branch.version, branch.name)
+		} else {
+			run("commit", "-q", "--allow-empty", "-m", "feature")
+		}
+	}
+	run("checkout", "-q", "main")
+	commit("1.0.1", "main")
+
+	refs, err := gitutil.Branches(dir, false)
+	assert.NoError(t, err)
+	m := &Matrix{
+		Dir:    dir,
+		Scheme: scheme.SemVer{},
+		Base:   "main",
+		Read: func(commit string) (string, error) {
+			content, _, err := gitutil.Show(dir, commit, "version")
+			return strings.TrimSpace(string(content)), err
+		},
+	}
+	branches, err := m.Build(refs)
+	assert.NoError(t, err)
+	var got []string
+	for _, b := range branches {
+		got = append(got, b.Name+" "+b.Version+" "+strings.Join(b.Problems, ", "))
+	}
+	assert.Equal(t, []string{
+		"release-a 1.1.0 same version as release-b",
+		"release-b 1.1.0 same version as release-a",
+		"main 1.0.1 ",
+		"feature 1.0.0 ",
+		"old 0.9.0 older than 1.0.0 where it branched off main",
+	}, got)
+}
@@ -0,0 +1,168 @@
+package buildnumber
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/pkg/errors"
+)
+
+// FileName is the state file holding the last build number, inside the .uvu folder
+const FileName = "build-number"
+
+// For Test Mocks
+var (
+	lockTimeout = 30 * time.Second
+	lockRetry   = 50 * time.Millisecond
+)
+
+// Counter is a build number that only ever increases, stored in a file that is committed with the version
+type Counter struct {
+	Path string
+}
+
+// Exists returns true if the state file has been created
+func (c *Counter) Exists() bool {
+	_, err := os.Stat(c.Path)
+	return err == nil
+}
+
+// Current returns the last build number handed out, 0 before the first one
+func (c *Counter) Current() (uint64, error) {
+	data, err := ioutil.ReadFile(c.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "reading %s", c.Path)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("%s should hold a build number but has %q", c.Path, text)
+	}
+	return n, nil
+}
+
+// Next increments the build number and returns it. The file is locked while it is read and written so
+// runs at the same time never get the same number.
+func (c *Counter) Next() (uint64, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	n, err := c.Current()
+	if err != nil {
+		return 0, err
+	}
+	n++
+	if err := c.write(n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Reserve locks the counter and plans writing the next build number, so the number is only used up if the
+// plan is applied. The returned unlock must be called once the plan was applied or given up.
+func (c *Counter) Reserve(plan *edit.Plan) (uint64, func(), error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, nil, err
+	}
+	n, err := c.Current()
+	if err != nil {
+		unlock()
+		return 0, nil, err
+	}
+	n++
+	err = plan.Edit(c.Path, "build number", func(content []byte) ([]byte, error) {
+		return []byte(fmt.Sprintf("%d\n", n)), nil
+	})
+	if err != nil {
+		unlock()
+		return 0, nil, err
+	}
+	return n, unlock, nil
+}
+
+// write replaces the file in one step so a reader never sees it half written
+func (c *Counter) write(n uint64) error {
+	dir := filepath.Dir(c.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "creating %s", dir)
+	}
+	tmp, err := ioutil.TempFile(dir, FileName+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "writing %s", c.Path)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = fmt.Fprintf(tmp, "%d\n", n)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "writing %s", c.Path)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return errors.Wrapf(err, "writing %s", c.Path)
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), c.Path), "writing %s", c.Path)
+}
+
+// lock creates the lock file of the counter, waiting for another run to remove it
+func (c *Counter) lock() (func(), error) {
+	path, err := c.lockPath()
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "locking %s", c.Path)
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for %s, delete it if no other run is using the build number %s", path, c.Path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// lockPath returns the lock file of the counter. It lives in the temporary folder, keyed by the absolute
+// path of the counter, so it is never committed next to the counter and a crash can't block other clones.
+func (c *Counter) lockPath() (string, error) {
+	abs, err := filepath.Abs(c.Path)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", c.Path)
+	}
+	sum := sha1.Sum([]byte(abs))
+	return filepath.Join(os.TempDir(), fmt.Sprintf("uvu-%s-%x.lock", FileName, sum[:8])), nil
+}
+
+// FromConfig returns the counter of the project and whether it is in use, either because the config
+// enables it or because its file exists. root is the folder holding .uvu, usually cfg.Root.
+func FromConfig(cfg *config.Config, root string) (*Counter, bool) {
+	file := cfg.BuildNumber.File
+	if file == "" {
+		file = filepath.Join(config.Dir, FileName)
+	}
+	answer := &Counter{Path: filepath.Join(root, file)}
+	return answer, cfg.BuildNumber.Enabled || answer.Exists()
+}
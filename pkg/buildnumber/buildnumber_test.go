@@ -0,0 +1,123 @@
+package buildnumber
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter_Next(t *testing.T) {
+	c := &Counter{Path: filepath.Join(t.TempDir(), ".uvu", FileName)}
+	assert.False(t, c.Exists())
+	n, err := c.Current()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), n)
+
+	n, err = c.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+	assert.True(t, c.Exists())
+
+	assert.NoError(t, ioutil.WriteFile(c.Path, []byte("1041\n"), 0644))
+	n, err = c.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1042), n)
+	data, err := ioutil.ReadFile(c.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, "1042\n", string(data))
+}
+
+func TestCounter_Reserve(t *testing.T) {
+	c := &Counter{Path: filepath.Join(t.TempDir(), ".uvu", FileName)}
+
+	// a plan that is never applied doesn't use up the number
+	lock, err := c.lockPath()
+	assert.NoError(t, err)
+	n, unlock, err := c.Reserve(edit.NewPlan())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+	assert.FileExists(t, lock)
+	unlock()
+	assert.False(t, c.Exists())
+
+	plan := edit.NewPlan()
+	n, unlock, err = c.Reserve(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+	assert.Contains(t, plan.Diff(), "+1")
+	assert.NoError(t, plan.Apply())
+	unlock()
+	assert.NoFileExists(t, lock)
+	n, err = c.Current()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+}
+
+func TestCounter_ReserveLeavesTheRepositoryClean(t *testing.T) {
+	dir := t.TempDir()
+	_, err := gitutil.Run(dir, "init", "-q")
+	assert.NoError(t, err)
+	c := &Counter{Path: filepath.Join(dir, ".uvu", FileName)}
+	assert.NoError(t, os.MkdirAll(filepath.Dir(c.Path), 0755))
+
+	_, unlock, err := c.Reserve(edit.NewPlan())
+	assert.NoError(t, err)
+	defer unlock()
+	status, err := gitutil.Run(dir, "status", "--porcelain", "--untracked-files=all")
+	assert.NoError(t, err)
+	assert.Empty(t, status)
+}
+
+func TestCounter_NextConcurrent(t *testing.T) {
+	c := &Counter{Path: filepath.Join(t.TempDir(), FileName)}
+	const runs = 20
+	numbers := make(chan uint64, runs)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := (&Counter{Path: c.Path}).Next()
+			assert.NoError(t, err)
+			numbers <- n
+		}()
+	}
+	wg.Wait()
+	close(numbers)
+	seen := map[uint64]bool{}
+	for n := range numbers {
+		assert.False(t, seen[n], "build number %d handed out twice", n)
+		seen[n] = true
+	}
+	n, err := c.Current()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(runs), n)
+}
+
+func TestCounter_Locked(t *testing.T) {
+	lockTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { lockTimeout = 30 * time.Second })
+
+	c := &Counter{Path: filepath.Join(t.TempDir(), FileName)}
+	lock, err := c.lockPath()
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(lock, []byte("1\n"), 0644))
+	t.Cleanup(func() { _ = os.Remove(lock) })
+	_, err = c.Next()
+	assert.Error(t, err)
+	assert.False(t, c.Exists())
+}
+
+func TestCounter_Invalid(t *testing.T) {
+	c := &Counter{Path: filepath.Join(t.TempDir(), FileName)}
+	assert.NoError(t, ioutil.WriteFile(c.Path, []byte("abc"), 0644))
+	_, err := c.Next()
+	assert.Error(t, err)
+}
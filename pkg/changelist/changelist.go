@@ -0,0 +1,103 @@
+package changelist
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SourceGit counts the commits reachable from HEAD
+	SourceGit = "git"
+	// SourceEnv reads the changelist from an environment variable
+	SourceEnv = "env"
+
+	// DefaultEnv is the variable the automation tool sets to the Perforce changelist of a build
+	DefaultEnv = "uebp_CL"
+)
+
+// Sources lists the values Source accepts
+var Sources = []string{SourceGit, SourceEnv}
+
+// Source says where the changelist of a version comes from
+type Source struct {
+	// Changelist is a Perforce changelist passed in, it wins over Kind when set
+	Changelist uint64
+	// Kind is SourceGit or SourceEnv, defaults to SourceGit
+	Kind string
+	// Env is the variable read by SourceEnv, defaults to DefaultEnv
+	Env string
+	// Branch is written after the changelist, a depot path such as //Game/Main is escaped to ++Game+Main
+	Branch string
+	// Rev is the commit SourceGit counts to, defaults to HEAD
+	Rev string
+}
+
+// Resolve returns the changelist for the project in dir
+func (s *Source) Resolve(dir string) (uint64, error) {
+	if s.Changelist > 0 {
+		return s.Changelist, nil
+	}
+	switch strings.ToLower(s.Kind) {
+	case "", SourceGit:
+		return commitCount(dir, s.Rev)
+	case SourceEnv:
+		name := s.Env
+		if name == "" {
+			name = DefaultEnv
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok || strings.TrimSpace(value) == "" {
+			return 0, errors.Errorf("the changelist comes from $%s but it is not set", name)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("$%s should be a changelist number but is %q", name, value)
+		}
+		return n, nil
+	}
+	return 0, errors.Errorf("unknown changelist source %q, expected one of %s", s.Kind, strings.Join(Sources, ", "))
+}
+
+// Fill sets the changelist and branch of the version unless it already has them
+func (s *Source) Fill(dir string, v *scheme.ChangelistVersion) error {
+	if v.Changelist == 0 {
+		n, err := s.Resolve(dir)
+		if err != nil {
+			return err
+		}
+		v.Changelist = n
+		log.Logger().Debugf("Using changelist %d", n)
+	}
+	if v.Branch == "" && s.Branch != "" {
+		v.Branch = scheme.EscapeBranch(s.Branch)
+	}
+	log.Logger().Debugf("Version with changelist %s", utils.ColorInfo(v.String()))
+	return nil
+}
+
+// commitCount stands in for a changelist in git, it increases with every commit on a branch. Shallow
+// clones count fewer commits so CI has to fetch the full history.
+func commitCount(dir string, rev string) (uint64, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if !gitutil.IsRepository(dir) {
+		return 0, errors.Errorf("%s is not a git repository, pass the changelist with --changelist or read it from an environment variable", dir)
+	}
+	out, err := gitutil.Run(dir, "rev-list", "--count", rev)
+	if err != nil {
+		return 0, errors.Wrap(err, "counting commits")
+	}
+	n, err := strconv.ParseUint(out, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("unexpected commit count %q", out)
+	}
+	return n, nil
+}
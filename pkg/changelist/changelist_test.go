@@ -0,0 +1,61 @@
+package changelist
+
+import (
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"config", "commit.gpgsign", "false"},
+		{"commit", "-q", "--allow-empty", "-m", "first"},
+		{"commit", "-q", "--allow-empty", "-m", "second"},
+	} {
+		_, err := gitutil.Run(dir, args...)
+		assert.NoError(t, err)
+	}
+	t.Setenv(DefaultEnv, "29314046")
+	t.Setenv("BAD_CL", "latest")
+
+	tests := []struct {
+		name    string
+		source  Source
+		dir     string
+		want    uint64
+		wantErr bool
+	}{
+		{"Git", Source{}, dir, 2, false},
+		{"Not a repository", Source{Kind: SourceGit}, t.TempDir(), 0, true},
+		{"Passed in", Source{Changelist: 12345}, t.TempDir(), 12345, false},
+		{"Default env", Source{Kind: SourceEnv}, dir, 29314046, false},
+		{"Missing env", Source{Kind: SourceEnv, Env: "NO_SUCH_CL"}, dir, 0, true},
+		{"Bad env", Source{Kind: SourceEnv, Env: "BAD_CL"}, dir, 0, true},
+		{"Unknown", Source{Kind: "p4"}, dir, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.Resolve(tt.dir)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFill(t *testing.T) {
+	source := &Source{Changelist: 42, Branch: "//Game/Main"}
+
+	v := &scheme.ChangelistVersion{Major: 1, Minor: 2, Patch: 3}
+	assert.NoError(t, source.Fill("", v))
+	assert.Equal(t, "1.2.3-42+++Game+Main", v.String())
+
+	v = &scheme.ChangelistVersion{Major: 1, Minor: 2, Patch: 3, Changelist: 7, Branch: "++UE5+Main"}
+	assert.NoError(t, source.Fill("", v))
+	assert.Equal(t, "1.2.3-7+++UE5+Main", v.String())
+}
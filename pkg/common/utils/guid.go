@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewGUID returns a random GUID as the 32 upper case hex digits unreal uses in config files
+func NewGUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%X", b), nil
+}
+
+// GUIDWithBraces formats a 32 digit GUID as {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+func GUIDWithBraces(guid string) string {
+	if len(guid) != 32 {
+		return guid
+	}
+	return fmt.Sprintf("{%s-%s-%s-%s-%s}", guid[0:8], guid[8:12], guid[12:16], guid[16:20], guid[20:32])
+}
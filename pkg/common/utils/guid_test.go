@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGUID(t *testing.T) {
+	guid, err := NewGUID()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-F]{32}$`), guid)
+
+	other, err := NewGUID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, guid, other)
+}
+
+func TestGUIDWithBraces(t *testing.T) {
+	assert.Equal(t, "{0C9A1B2C-4D5E-6F70-8192-A3B4C5D6E7F8}", GUIDWithBraces("0C9A1B2C4D5E6F708192A3B4C5D6E7F8"))
+	assert.Equal(t, "short", GUIDWithBraces("short"))
+}
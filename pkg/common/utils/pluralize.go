@@ -0,0 +1,11 @@
+package utils
+
+import "fmt"
+
+// Pluralize returns the count followed by the singular or plural noun, such as 1 file or 2 files
+func Pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
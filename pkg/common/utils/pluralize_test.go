@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralize(t *testing.T) {
+	assert.Equal(t, "0 files", Pluralize(0, "file", "files"))
+	assert.Equal(t, "1 file", Pluralize(1, "file", "files"))
+	assert.Equal(t, "2 files", Pluralize(2, "file", "files"))
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggestions returns the options that look like a misspelling of name, closest first
+func Suggestions(name string, options []string) []string {
+	type scored struct {
+		option   string
+		distance int
+	}
+	lower := strings.ToLower(name)
+	var matches []scored
+	for _, option := range options {
+		optionLower := strings.ToLower(option)
+		distance := levenshtein(lower, optionLower)
+		if distance <= 2 || strings.Contains(optionLower, lower) || strings.Contains(lower, optionLower) {
+			matches = append(matches, scored{option, distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	var answer []string
+	for _, match := range matches {
+		answer = append(answer, match.option)
+	}
+	return answer
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	answer := values[0]
+	for _, v := range values[1:] {
+		if v < answer {
+			answer = v
+		}
+	}
+	return answer
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestions(t *testing.T) {
+	options := []string{"CompanyName", "CopyrightNotice", "Description", "ProjectName", "ProjectVersion"}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"ProjectVerison", []string{"ProjectVersion"}},
+		{"companyname", []string{"CompanyName"}},
+		{"Project", []string{"ProjectName", "ProjectVersion"}},
+		{"Burrito", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Suggestions(tt.name, options))
+		})
+	}
+}
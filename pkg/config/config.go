@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Dir is the folder next to the project holding the tool's config and state
+	Dir = ".uvu"
+
+	// FileName is the name of the config file inside Dir
+	FileName = "config.yaml"
+)
+
+// Template renders a value into an ini key whenever the version is set
+type Template struct {
+	// File is the ini file relative to the Config folder, defaults to the file holding the version
+	File string `mapstructure:"file"`
+	// Section defaults to GeneralProjectSettings
+	Section string `mapstructure:"section"`
+	Key     string `mapstructure:"key"`
+	// Template is a go template such as `{{.ProjectName}} {{.Version}} ({{.GitSHA}})`
+	Template string `mapstructure:"template"`
+}
+
+// Normalize configures how the version given on the command line is cleaned up before it is parsed
+type Normalize struct {
+	// ReleasePrefixes are stripped from the version such as release- in release-1.2.3, defaults to
+	// scheme.DefaultReleasePrefixes
+	ReleasePrefixes []string `mapstructure:"release-prefixes"`
+	// Extract uses a version found inside the input when it isn't one once the prefixes are stripped
+	Extract bool `mapstructure:"extract"`
+	// ExtractPrerelease keeps the text after the numbers of an extracted version, such as -rc.1
+	ExtractPrerelease bool `mapstructure:"extract-prerelease"`
+}
+
+// Changelist configures the changelist and branch of the changelist scheme
+type Changelist struct {
+	// Source is git to count commits or env to read Env, defaults to git
+	Source string `mapstructure:"source"`
+	// Env is the variable holding the changelist, defaults to uebp_CL
+	Env string `mapstructure:"env"`
+	// Branch such as //Game/Main is written after the changelist
+	Branch string `mapstructure:"branch"`
+}
+
+// BuildNumber configures the build number counter
+type BuildNumber struct {
+	// Enabled increments the counter every time the version is set, it is also on once the file exists
+	Enabled bool `mapstructure:"enabled"`
+	// File is the state file relative to the folder holding .uvu, defaults to .uvu/build-number
+	File string `mapstructure:"file"`
+}
+
+// RequireBump configures which changes require-bump expects a new version for
+type RequireBump struct {
+	// Paths are the files that need a new version when they change, defaults to pathfilter.DefaultInclude
+	Paths []string `mapstructure:"paths"`
+	// Ignore are files that never need one, such as docs inside Content
+	Ignore []string `mapstructure:"ignore"`
+}
+
+// TextTarget writes the version into any text file, such as a README badge or an installer script
+type TextTarget struct {
+	// Files selects the files such as README.md or Docs/*.md
+	Files string `mapstructure:"files"`
+	// Pattern is a regular expression with a named group version, the text that group matches is replaced
+	Pattern string `mapstructure:"pattern"`
+	// Replace is a go template rendered in place of the version group, defaults to {{.Version}}
+	Replace string `mapstructure:"replace"`
+}
+
+// FileTarget writes the version to a value of a JSON or TOML file, keeping the formatting and key order
+type FileTarget struct {
+	// File selects the files such as package.json or Tools/*/Cargo.toml
+	File string `mapstructure:"file"`
+	// Format is json or toml, defaults to the extension of the file
+	Format string `mapstructure:"format"`
+	// Path locates the value such as $.version, build.version or bin[0].version
+	Path string `mapstructure:"path"`
+	// Template is a go template rendered into the value, defaults to {{.Version}}
+	Template string `mapstructure:"template"`
+}
+
+// Steam configures the SteamCMD app build files updated whenever the version is set
+type Steam struct {
+	// Files selects the app build files such as Steam/app_build_*.vdf, steam is off without it
+	Files string `mapstructure:"files"`
+	// Desc is a go template for the build description, defaults to {{.ProjectName}} {{.Version}}
+	Desc string `mapstructure:"desc"`
+	// Branches maps a prerelease such as beta to the branch SetLive sets the build live on, a prerelease
+	// that isn't listed is used as the branch name
+	Branches map[string]string `mapstructure:"branches"`
+	// ReleaseBranch is the SetLive branch of versions without a prerelease, by default they aren't set live
+	ReleaseBranch string `mapstructure:"release-branch"`
+}
+
+// Config is the tool's config file. Text targets, file targets and steam select their files with
+// pathfilter.Glob patterns below Root.
+type Config struct {
+	// Path is the file the config was read from, empty if there is none
+	Path string `mapstructure:"-"`
+	// Scheme is the version scheme, see scheme.Names
+	Scheme      string       `mapstructure:"scheme"`
+	Normalize   Normalize    `mapstructure:"normalize"`
+	Changelist  Changelist   `mapstructure:"changelist"`
+	BuildNumber BuildNumber  `mapstructure:"build-number"`
+	RequireBump RequireBump  `mapstructure:"require-bump"`
+	Templates   []Template   `mapstructure:"templates"`
+	TextTargets []TextTarget `mapstructure:"text-targets"`
+	FileTargets []FileTarget `mapstructure:"file-targets"`
+	Steam       Steam        `mapstructure:"steam"`
+}
+
+// Root returns the folder holding .uvu, the project folder when there is no config file
+func (c *Config) Root(projectDir string) string {
+	if c.Path == "" {
+		return projectDir
+	}
+	return filepath.Dir(filepath.Dir(c.Path))
+}
+
+// Find looks for .uvu/config.yaml in dir and its parents, returning an empty string if there is none
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", dir)
+	}
+	for {
+		path := filepath.Join(dir, Dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads the config file at path, an empty path gives the default config
+func Load(path string) (*Config, error) {
+	answer := &Config{Path: path}
+	if path == "" {
+		return answer, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	if err := v.Unmarshal(answer); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	for i, template := range answer.Templates {
+		if template.Key == "" {
+			return nil, errors.Errorf("%s: template %d has no key", path, i+1)
+		}
+	}
+	for i, target := range answer.TextTargets {
+		if target.Files == "" || target.Pattern == "" {
+			return nil, errors.Errorf("%s: text target %d needs files and a pattern", path, i+1)
+		}
+	}
+	for i, target := range answer.FileTargets {
+		if target.File == "" || target.Path == "" {
+			return nil, errors.Errorf("%s: file target %d needs a file and a path", path, i+1)
+		}
+	}
+	return answer, nil
+}
+
+// FindAndLoad loads the config found from dir, or the file given explicitly
+func FindAndLoad(dir string, explicit string) (*Config, error) {
+	path := explicit
+	if path == "" {
+		var err error
+		if path, err = Find(dir); err != nil {
+			return nil, err
+		}
+	}
+	return Load(path)
+}
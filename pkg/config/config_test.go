@@ -0,0 +1,89 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAndLoad(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "Games", "Shooter")
+	assert.NoError(t, os.MkdirAll(nested, 0755))
+
+	cfg, err := FindAndLoad(nested, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{}, cfg)
+
+	path := filepath.Join(root, Dir, FileName)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`scheme: calver
+normalize:
+  release-prefixes: [shooter-]
+  extract: true
+build-number:
+  enabled: true
+templates:
+  - key: ProjectDisplayedTitle
+    template: "{{.ProjectName}} {{.Version}}"
+  - file: DefaultEngine.ini
+    section: /Script/Engine.Engine
+    key: BuildLabel
+    template: "{{.GitSHA}}"
+text-targets:
+  - files: README.md
+    pattern: 'version-(?P<version>[0-9.]+)-blue'
+file-targets:
+  - file: Web/package.json
+    path: $.version
+  - file: Tools/manifest.cfg
+    format: toml
+    path: build.version
+    template: "{{.Version}}+{{.GitSHA}}"
+steam:
+  files: Steam/app_build_*.vdf
+  branches:
+    rc: staging
+`), 0644))
+
+	cfg, err = FindAndLoad(nested, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		Path:   path,
+		Scheme: "calver",
+		Normalize: Normalize{
+			ReleasePrefixes: []string{"shooter-"},
+			Extract:         true,
+		},
+		BuildNumber: BuildNumber{Enabled: true},
+		Templates: []Template{
+			{Key: "ProjectDisplayedTitle", Template: "{{.ProjectName}} {{.Version}}"},
+			{File: "DefaultEngine.ini", Section: "/Script/Engine.Engine", Key: "BuildLabel", Template: "{{.GitSHA}}"},
+		},
+		TextTargets: []TextTarget{
+			{Files: "README.md", Pattern: "version-(?P<version>[0-9.]+)-blue"},
+		},
+		FileTargets: []FileTarget{
+			{File: "Web/package.json", Path: "$.version"},
+			{File: "Tools/manifest.cfg", Format: "toml", Path: "build.version", Template: "{{.Version}}+{{.GitSHA}}"},
+		},
+		Steam: Steam{Files: "Steam/app_build_*.vdf", Branches: map[string]string{"rc": "staging"}},
+	}, cfg)
+	assert.Equal(t, root, cfg.Root(nested))
+
+	bad := filepath.Join(root, "bad.yaml")
+	assert.NoError(t, ioutil.WriteFile(bad, []byte("templates:\n  - template: x\n"), 0644))
+	_, err = FindAndLoad(nested, bad)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(bad, []byte("text-targets:\n  - files: README.md\n"), 0644))
+	_, err = FindAndLoad(nested, bad)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(bad, []byte("file-targets:\n  - file: package.json\n"), 0644))
+	_, err = FindAndLoad(nested, bad)
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package descriptor
+
+import (
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+)
+
+// Compatibility describes how a plugin's EngineVersion relates to the project's engine
+type Compatibility string
+
+const (
+	// Compatible plugins were built for the same major and minor engine version
+	Compatible Compatibility = "compatible"
+	// Older plugins were built for an earlier engine
+	Older Compatibility = "older"
+	// Newer plugins were built for a later engine
+	Newer Compatibility = "newer"
+	// Unspecified plugins don't declare an EngineVersion, unreal loads them with any engine
+	Unspecified Compatibility = "unspecified"
+	// Invalid plugins declare an EngineVersion that can't be parsed
+	Invalid Compatibility = "invalid"
+)
+
+// CheckCompatibility compares the plugin's EngineVersion with the engine the project uses
+func (p *Plugin) CheckCompatibility(engine *engines.BuildVersion) Compatibility {
+	if p.EngineVersion == "" {
+		return Unspecified
+	}
+	version, err := engines.ParseEngineVersion(p.EngineVersion)
+	if err != nil {
+		return Invalid
+	}
+	switch version.CompareMajorMinor(engine) {
+	case -1:
+		return Older
+	case 1:
+		return Newer
+	}
+	return Compatible
+}
+
+// IsProblem returns true if the plugin needs attention before it will load cleanly
+func (c Compatibility) IsProblem() bool {
+	return c == Older || c == Newer || c == Invalid
+}
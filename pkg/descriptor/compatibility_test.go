@@ -0,0 +1,32 @@
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlugin_CheckCompatibility(t *testing.T) {
+	engine := &engines.BuildVersion{MajorVersion: 5, MinorVersion: 3, PatchVersion: 2}
+	tests := []struct {
+		engineVersion string
+		want          Compatibility
+	}{
+		{"5.3.0", Compatible},
+		{"5.3", Compatible},
+		{"5.2.1", Older},
+		{"4.27.2", Older},
+		{"5.4.0", Newer},
+		{"", Unspecified},
+		{"latest", Invalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engineVersion, func(t *testing.T) {
+			plugin := &Plugin{EngineVersion: tt.engineVersion}
+			got := plugin.CheckCompatibility(engine)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want == Older || tt.want == Newer || tt.want == Invalid, got.IsProblem())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package descriptor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PluginExtension is the file extension of an unreal plugin descriptor
+	PluginExtension = ".uplugin"
+
+	// EngineVersionKey is the plugin descriptor key holding the engine version the plugin was built for
+	EngineVersionKey = "EngineVersion"
+
+	// VersionKey is the plugin descriptor key holding the plugin's version number, which has to increase
+	// with every release
+	VersionKey = "Version"
+
+	// VersionNameKey is the plugin descriptor key holding the version shown to users
+	VersionNameKey = "VersionName"
+
+	// PluginsDir is the folder of a project holding its plugins
+	PluginsDir = "Plugins"
+
+	// MarketplaceDir is the folder the launcher installs marketplace plugins into
+	MarketplaceDir = "Marketplace"
+)
+
+// Plugin is the content of a .uplugin file
+type Plugin struct {
+	Path           string   `json:"-"`
+	Content        []byte   `json:"-"`
+	FileVersion    int      `json:"FileVersion"`
+	Version        int      `json:"Version"`
+	VersionName    string   `json:"VersionName"`
+	FriendlyName   string   `json:"FriendlyName"`
+	CreatedBy      string   `json:"CreatedBy"`
+	EngineVersion  string   `json:"EngineVersion"`
+	MarketplaceURL string   `json:"MarketplaceURL"`
+	Installed      bool     `json:"Installed"`
+	Modules        []Module `json:"Modules"`
+}
+
+// LoadPlugin reads and parses a .uplugin file
+func LoadPlugin(path string) (*Plugin, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading plugin %s", path)
+	}
+	return ParsePlugin(path, data)
+}
+
+// ParsePlugin parses the content of the .uplugin file at path
+func ParsePlugin(path string, data []byte) (*Plugin, error) {
+	plugin := &Plugin{}
+	if err := json.Unmarshal(data, plugin); err != nil {
+		return nil, errors.Wrapf(err, "parsing plugin %s", path)
+	}
+	plugin.Path = path
+	plugin.Content = data
+	return plugin, nil
+}
+
+// FindPlugin returns the single .uplugin file in the given directory
+func FindPlugin(dir string) (string, error) {
+	return findDescriptor(dir, PluginExtension)
+}
+
+// Name returns the plugin name, which unreal derives from the descriptor file name
+func (p *Plugin) Name() string {
+	return NameFromPath(p.Path)
+}
+
+// IsFirstParty returns true for plugins the project owns, as opposed to ones installed from the marketplace
+func (p *Plugin) IsFirstParty() bool {
+	if p.MarketplaceURL != "" || p.Installed {
+		return false
+	}
+	for _, part := range strings.Split(filepath.ToSlash(p.Path), "/") {
+		if part == MarketplaceDir {
+			return false
+		}
+	}
+	return true
+}
+
+// FindPlugins returns every .uplugin below the Plugins folder of the project, sorted by path
+func FindPlugins(projectDir string) ([]string, error) {
+	root := filepath.Join(projectDir, PluginsDir)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var answer []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// unreal doesn't look for plugins inside another plugin's content or build folders
+			switch info.Name() {
+			case "Binaries", "Intermediate", "Content", "Source", "Saved":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == PluginExtension {
+			answer = append(answer, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching %s for plugins", root)
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// LoadPlugins reads every plugin of the project
+func LoadPlugins(projectDir string) ([]*Plugin, error) {
+	paths, err := FindPlugins(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	var answer []*Plugin
+	for _, path := range paths {
+		plugin, err := LoadPlugin(path)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, plugin)
+	}
+	return answer, nil
+}
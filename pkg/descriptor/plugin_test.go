@@ -0,0 +1,40 @@
+package descriptor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writePlugin(t *testing.T, path string, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadPlugins(t *testing.T) {
+	dir := t.TempDir()
+	plugins, err := LoadPlugins(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+
+	writePlugin(t, filepath.Join(dir, "Plugins", "Tools", "Tools.uplugin"), `{"FileVersion": 3, "Version": 2, "VersionName": "1.1", "EngineVersion": "5.2.0"}`)
+	writePlugin(t, filepath.Join(dir, "Plugins", "Marketplace", "Shop", "Shop.uplugin"), `{"FileVersion": 3, "EngineVersion": "5.3.0"}`)
+	writePlugin(t, filepath.Join(dir, "Plugins", "Store", "Store.uplugin"), `{"FileVersion": 3, "MarketplaceURL": "com.epicgames.launcher://ue/marketplace/content/abc"}`)
+	writePlugin(t, filepath.Join(dir, "Plugins", "Tools", "Content", "Nested", "Nested.uplugin"), `{}`)
+
+	plugins, err = LoadPlugins(dir)
+	assert.NoError(t, err)
+	var names []string
+	var firstParty []bool
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name())
+		firstParty = append(firstParty, plugin.IsFirstParty())
+	}
+	assert.Equal(t, []string{"Shop", "Store", "Tools"}, names)
+	assert.Equal(t, []bool{false, false, true}, firstParty)
+	assert.Equal(t, 2, plugins[2].Version)
+	assert.Equal(t, "5.2.0", plugins[2].EngineVersion)
+}
@@ -0,0 +1,108 @@
+package descriptor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/jsonedit"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectExtension is the file extension of an unreal project descriptor
+	ProjectExtension = ".uproject"
+
+	// EngineAssociationKey is the descriptor key holding the engine a project is built with
+	EngineAssociationKey = "EngineAssociation"
+)
+
+// Module is a code module listed in a project or plugin descriptor
+type Module struct {
+	Name         string `json:"Name"`
+	Type         string `json:"Type"`
+	LoadingPhase string `json:"LoadingPhase,omitempty"`
+}
+
+// PluginReference is a plugin enabled or disabled by a project
+type PluginReference struct {
+	Name    string `json:"Name"`
+	Enabled bool   `json:"Enabled"`
+}
+
+// Project is the content of a .uproject file
+type Project struct {
+	Path              string            `json:"-"`
+	Content           []byte            `json:"-"`
+	FileVersion       int               `json:"FileVersion"`
+	EngineAssociation string            `json:"EngineAssociation"`
+	Category          string            `json:"Category"`
+	Description       string            `json:"Description"`
+	Modules           []Module          `json:"Modules"`
+	Plugins           []PluginReference `json:"Plugins"`
+}
+
+// FindProject returns the single .uproject file in the given directory
+func FindProject(dir string) (string, error) {
+	return findDescriptor(dir, ProjectExtension)
+}
+
+func findDescriptor(dir string, extension string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+extension))
+	if err != nil {
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		return "", errors.Errorf("no %s file found in %s", extension, dir)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", errors.Errorf("found %d %s files in %s, please specify which one to use", len(matches), extension, dir)
+	}
+}
+
+// LoadProject reads and parses a .uproject file
+func LoadProject(path string) (*Project, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading project %s", path)
+	}
+	return ParseProject(path, data)
+}
+
+// ParseProject parses the content of the .uproject file at path
+func ParseProject(path string, data []byte) (*Project, error) {
+	project := &Project{}
+	if err := json.Unmarshal(data, project); err != nil {
+		return nil, errors.Wrapf(err, "parsing project %s", path)
+	}
+	project.Path = path
+	project.Content = data
+	return project, nil
+}
+
+// Name returns the project name, which unreal derives from the descriptor file name
+func (p *Project) Name() string {
+	return NameFromPath(p.Path)
+}
+
+// Dir returns the project root directory
+func (p *Project) Dir() string {
+	return filepath.Dir(p.Path)
+}
+
+// NameFromPath returns the descriptor file name without its extension
+func NameFromPath(path string) string {
+	base := filepath.Base(path)
+	return base[:len(base)-len(filepath.Ext(base))]
+}
+
+// SetField returns the descriptor content with the given field changed, keeping the original formatting
+func SetField(content []byte, key string, value interface{}) ([]byte, error) {
+	answer, err := jsonedit.Set(content, key, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "setting %s", key)
+	}
+	return answer, nil
+}
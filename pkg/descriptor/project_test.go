@@ -0,0 +1,44 @@
+package descriptor
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindProject(t *testing.T) {
+	dir := t.TempDir()
+	_, err := FindProject(dir)
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "MyGame.uproject")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"FileVersion": 3, "EngineAssociation": "5.2"}`), 0644))
+	got, err := FindProject(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, path, got)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Other.uproject"), []byte(`{}`), 0644))
+	_, err = FindProject(dir)
+	assert.Error(t, err)
+}
+
+func TestLoadProject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "MyGame.uproject")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{
+	"FileVersion": 3,
+	"EngineAssociation": "5.2",
+	"Modules": [{"Name": "MyGame", "Type": "Runtime", "LoadingPhase": "Default"}]
+}`), 0644))
+
+	project, err := LoadProject(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "MyGame", project.Name())
+	assert.Equal(t, "5.2", project.EngineAssociation)
+	assert.Equal(t, []Module{{Name: "MyGame", Type: "Runtime", LoadingPhase: "Default"}}, project.Modules)
+
+	content, err := SetField(project.Content, EngineAssociationKey, "5.3")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\t\"EngineAssociation\": \"5.3\",\n")
+}
@@ -0,0 +1,77 @@
+package descriptor
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TargetRulesSuffix is the file name suffix of the C# target rules of a project
+	TargetRulesSuffix = ".Target.cs"
+
+	// SourceDir is the folder of a project holding its code
+	SourceDir = "Source"
+
+	latest = "Latest"
+)
+
+var (
+	defaultBuildSettingsRegex = regexp.MustCompile(`(DefaultBuildSettings\s*=\s*BuildSettingsVersion\.)(\w+)`)
+	includeOrderVersionRegex  = regexp.MustCompile(`(IncludeOrderVersion\s*=\s*EngineIncludeOrderVersion\.)(\w+)`)
+)
+
+// FindTargetRules returns the *.Target.cs files of the project sorted by path
+func FindTargetRules(projectDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(projectDir, SourceDir, "*"+TargetRulesSuffix))
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching %s for target rules", projectDir)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// BuildSettingsVersion returns the BuildSettingsVersion the engine's own templates use
+func BuildSettingsVersion(engine *engines.BuildVersion) string {
+	switch {
+	case engine.MajorVersion < 5 || (engine.MajorVersion == 5 && engine.MinorVersion <= 2):
+		return "V2"
+	case engine.MajorVersion == 5 && engine.MinorVersion == 3:
+		return "V4"
+	default:
+		return "V5"
+	}
+}
+
+// IncludeOrderVersion returns the EngineIncludeOrderVersion matching the engine, or an empty string for
+// engines older than 5.1 which don't have the setting
+func IncludeOrderVersion(engine *engines.BuildVersion) string {
+	if engine.MajorVersion < 5 || (engine.MajorVersion == 5 && engine.MinorVersion < 1) {
+		return ""
+	}
+	return fmt.Sprintf("Unreal%d_%d", engine.MajorVersion, engine.MinorVersion)
+}
+
+// SetTargetRulesVersions points DefaultBuildSettings and IncludeOrderVersion of a Target.cs at the engine.
+// Targets that opted into `Latest` keep it, and settings missing from the file are not added.
+func SetTargetRulesVersions(content []byte, engine *engines.BuildVersion) []byte {
+	content = replaceEnumValue(defaultBuildSettingsRegex, content, BuildSettingsVersion(engine))
+	if includeOrder := IncludeOrderVersion(engine); includeOrder != "" {
+		content = replaceEnumValue(includeOrderVersionRegex, content, includeOrder)
+	}
+	return content
+}
+
+func replaceEnumValue(regex *regexp.Regexp, content []byte, value string) []byte {
+	return regex.ReplaceAllFunc(content, func(match []byte) []byte {
+		groups := regex.FindSubmatch(match)
+		if string(groups[2]) == latest {
+			return match
+		}
+		return append(append([]byte{}, groups[1]...), value...)
+	})
+}
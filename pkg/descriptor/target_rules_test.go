@@ -0,0 +1,44 @@
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/stretchr/testify/assert"
+)
+
+const gameTarget = `using UnrealBuildTool;
+
+public class MyGameTarget : TargetRules
+{
+	public MyGameTarget(TargetInfo Target) : base(Target)
+	{
+		Type = TargetType.Game;
+		DefaultBuildSettings = BuildSettingsVersion.V2;
+		IncludeOrderVersion = EngineIncludeOrderVersion.Unreal5_2;
+		ExtraModuleNames.Add("MyGame");
+	}
+}
+`
+
+func TestSetTargetRulesVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  *engines.BuildVersion
+		content string
+		want    []string
+	}{
+		{"5.3", &engines.BuildVersion{MajorVersion: 5, MinorVersion: 3}, gameTarget, []string{"BuildSettingsVersion.V4;", "EngineIncludeOrderVersion.Unreal5_3;"}},
+		{"5.4", &engines.BuildVersion{MajorVersion: 5, MinorVersion: 4}, gameTarget, []string{"BuildSettingsVersion.V5;", "EngineIncludeOrderVersion.Unreal5_4;"}},
+		{"5.0 leaves include order", &engines.BuildVersion{MajorVersion: 5, MinorVersion: 0}, gameTarget, []string{"BuildSettingsVersion.V2;", "EngineIncludeOrderVersion.Unreal5_2;"}},
+		{"Latest", &engines.BuildVersion{MajorVersion: 5, MinorVersion: 4}, "IncludeOrderVersion = EngineIncludeOrderVersion.Latest;", []string{"EngineIncludeOrderVersion.Latest;"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(SetTargetRulesVersions([]byte(tt.content), tt.engine))
+			for _, want := range tt.want {
+				assert.Contains(t, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	type args struct {
+		before string
+		after  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"Same", args{"a\nb\n", "a\nb\n"}, ""},
+		{"Change", args{"[Section]\nA=1\nProjectVersion=1.0.0\nB=2\n", "[Section]\nA=1\nProjectVersion=1.1.0\nB=2\n"}, `--- old
++++ new
+@@ -1,4 +1,4 @@
+ [Section]
+ A=1
+-ProjectVersion=1.0.0
++ProjectVersion=1.1.0
+ B=2
+`},
+		{"Two hunks", args{"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n"}, `--- old
++++ new
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`},
+		{"Created", args{"", "a\n"}, `--- old
++++ new
+@@ -0,0 +1,1 @@
++a
+`},
+		{"No newline", args{"a", "b"}, `--- old
++++ new
+@@ -1,1 +1,1 @@
+-a
+\ No newline at end of file
++b
+\ No newline at end of file
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("old", "new", []byte(tt.args.before), []byte(tt.args.after))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
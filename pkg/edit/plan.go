@@ -0,0 +1,314 @@
+package edit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Change is a planned rewrite, creation or deletion of one file
+type Change struct {
+	Path string
+	// Before is the content the file had when the change was planned, nil if the file is being created
+	Before []byte
+	// After is the new content, nil if the file is being deleted
+	After []byte
+	// Reasons explains why the file changes, one entry per edit made to it
+	Reasons []string
+}
+
+// Created returns true if the change creates a new file
+func (c *Change) Created() bool {
+	return c.Before == nil
+}
+
+// Deleted returns true if the change removes the file
+func (c *Change) Deleted() bool {
+	return c.After == nil
+}
+
+// Diff returns the change in unified diff format
+func (c *Change) Diff() string {
+	oldName, newName := "a/"+filepath.ToSlash(c.Path), "b/"+filepath.ToSlash(c.Path)
+	if c.Created() {
+		oldName = "/dev/null"
+	}
+	if c.Deleted() {
+		newName = "/dev/null"
+	}
+	return UnifiedDiff(oldName, newName, c.Before, c.After)
+}
+
+// Plan collects file changes so they can be reviewed as a diff and then applied together
+type Plan struct {
+	changes map[string]*Change
+}
+
+// NewPlan creates an empty plan
+func NewPlan() *Plan {
+	return &Plan{changes: map[string]*Change{}}
+}
+
+// Content returns the content of the file with the changes planned so far, so several edits to the
+// same file build on each other
+func (p *Plan) Content(path string) ([]byte, error) {
+	if change, ok := p.changes[filepath.Clean(path)]; ok {
+		if change.Deleted() {
+			return nil, errors.Errorf("%s is deleted by the plan", path)
+		}
+		return change.After, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return data, nil
+}
+
+// Update plans new content for an existing file
+func (p *Plan) Update(path string, after []byte, reason string) error {
+	change, err := p.change(path)
+	if err != nil {
+		return err
+	}
+	if change.Deleted() {
+		return errors.Errorf("%s is deleted by the plan", path)
+	}
+	change.After = after
+	change.Reasons = append(change.Reasons, reason)
+	return nil
+}
+
+// Create plans a new file
+func (p *Plan) Create(path string, content []byte, reason string) error {
+	path = filepath.Clean(path)
+	change, ok := p.changes[path]
+	switch {
+	case ok && !change.Deleted():
+		return errors.Errorf("%s already exists", path)
+	case !ok:
+		if _, err := os.Stat(path); err == nil {
+			return errors.Errorf("%s already exists", path)
+		}
+		change = &Change{Path: path}
+		p.changes[path] = change
+	}
+	if content == nil {
+		content = []byte{}
+	}
+	change.After = content
+	change.Reasons = append(change.Reasons, reason)
+	return nil
+}
+
+// Edit plans new content for a file computed from its current content, a file that doesn't exist yet is
+// edited starting from empty content and created
+func (p *Plan) Edit(path string, reason string, edit func(content []byte) ([]byte, error)) error {
+	exists := true
+	content, err := p.Content(path)
+	if err != nil {
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			return err
+		}
+		exists = false
+		content = []byte{}
+	}
+	after, err := edit(content)
+	if err != nil {
+		return errors.Wrapf(err, "updating %s", path)
+	}
+	if !exists {
+		return p.Create(path, after, reason)
+	}
+	return p.Update(path, after, reason)
+}
+
+// Delete plans the removal of a file
+func (p *Plan) Delete(path string, reason string) error {
+	change, err := p.change(path)
+	if err != nil {
+		return err
+	}
+	if change.Created() {
+		delete(p.changes, change.Path)
+		return nil
+	}
+	change.After = nil
+	change.Reasons = append(change.Reasons, reason)
+	return nil
+}
+
+// Rename plans moving a file, keeping any edits already planned for it
+func (p *Plan) Rename(from, to string, reason string) error {
+	content, err := p.Content(from)
+	if err != nil {
+		return err
+	}
+	if err := p.Create(to, content, reason); err != nil {
+		return err
+	}
+	return p.Delete(from, reason)
+}
+
+func (p *Plan) change(path string) (*Change, error) {
+	path = filepath.Clean(path)
+	if change, ok := p.changes[path]; ok {
+		return change, nil
+	}
+	before, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	change := &Change{Path: path, Before: before, After: before}
+	p.changes[path] = change
+	return change, nil
+}
+
+// Changes returns the changes that actually modify a file, sorted by path
+func (p *Plan) Changes() []*Change {
+	var answer []*Change
+	for _, change := range p.changes {
+		if change.Created() || change.Deleted() || !bytes.Equal(change.Before, change.After) {
+			answer = append(answer, change)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Path < answer[j].Path
+	})
+	return answer
+}
+
+// Empty returns true if applying the plan would not change anything
+func (p *Plan) Empty() bool {
+	return len(p.Changes()) == 0
+}
+
+// Diff returns every change of the plan in unified diff format
+func (p *Plan) Diff() string {
+	var b strings.Builder
+	for _, change := range p.Changes() {
+		b.WriteString(change.Diff())
+	}
+	return b.String()
+}
+
+// Apply writes every change. Files are first written next to their destination and only renamed into
+// place once all of them were written, if anything fails the files already replaced are restored so the
+// plan is applied completely or not at all.
+func (p *Plan) Apply() error {
+	changes := p.Changes()
+	for _, change := range changes {
+		if err := change.checkUnchanged(); err != nil {
+			return err
+		}
+	}
+
+	var staged []string
+	cleanup := func() {
+		for _, tmp := range staged {
+			_ = os.Remove(tmp)
+		}
+	}
+	for _, change := range changes {
+		if change.Deleted() {
+			staged = append(staged, "")
+			continue
+		}
+		tmp, err := change.stage()
+		if err != nil {
+			cleanup()
+			return err
+		}
+		staged = append(staged, tmp)
+	}
+
+	for i, change := range changes {
+		var err error
+		if change.Deleted() {
+			err = os.Remove(change.Path)
+		} else {
+			err = os.Rename(staged[i], change.Path)
+		}
+		if err != nil {
+			rollback(changes[:i])
+			cleanup()
+			return errors.Wrapf(err, "applying change to %s", change.Path)
+		}
+		staged[i] = ""
+	}
+	for _, change := range changes {
+		if change.Deleted() {
+			removeEmptyParents(change.Path)
+		}
+	}
+	return nil
+}
+
+// removeEmptyParents removes the folders a deleted file leaves empty, such as the old folder of a
+// renamed module
+func removeEmptyParents(path string) {
+	for dir := filepath.Dir(path); dir != "." && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// checkUnchanged makes sure nothing else modified the file since the change was planned
+func (c *Change) checkUnchanged() error {
+	current, err := ioutil.ReadFile(c.Path)
+	if c.Created() {
+		if err == nil {
+			return errors.Errorf("%s was created by something else while planning", c.Path)
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", c.Path)
+	}
+	if !bytes.Equal(current, c.Before) {
+		return errors.Errorf("%s was modified by something else while planning", c.Path)
+	}
+	return nil
+}
+
+func (c *Change) stage() (string, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(c.Path); err == nil {
+		mode = info.Mode()
+	}
+	dir := filepath.Dir(c.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.Wrapf(err, "creating %s", dir)
+	}
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(c.Path)+".*.tmp")
+	if err != nil {
+		return "", errors.Wrapf(err, "staging %s", c.Path)
+	}
+	defer tmp.Close()
+	if _, err := tmp.Write(c.After); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", errors.Wrapf(err, "staging %s", c.Path)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", errors.Wrapf(err, "staging %s", c.Path)
+	}
+	return tmp.Name(), nil
+}
+
+// rollback puts back the original content of files that were already changed
+func rollback(applied []*Change) {
+	for _, change := range applied {
+		if change.Created() {
+			_ = os.Remove(change.Path)
+			continue
+		}
+		_ = ioutil.WriteFile(change.Path, change.Before, 0644)
+	}
+}
@@ -0,0 +1,90 @@
+package edit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan_Apply(t *testing.T) {
+	dir := t.TempDir()
+	game := filepath.Join(dir, "Game.uproject")
+	ini := filepath.Join(dir, "Config", "DefaultGame.ini")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(ini), 0755))
+	assert.NoError(t, ioutil.WriteFile(game, []byte("{\"EngineAssociation\": \"5.2\"}\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(ini, []byte("ProjectVersion=1.0.0\n"), 0644))
+
+	plan := NewPlan()
+	assert.True(t, plan.Empty())
+
+	assert.NoError(t, plan.Update(ini, []byte("ProjectVersion=1.0.0\n"), "unchanged"))
+	assert.True(t, plan.Empty())
+
+	assert.NoError(t, plan.Update(game, []byte("{\"EngineAssociation\": \"5.3\"}\n"), "engine"))
+	content, err := plan.Content(game)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"EngineAssociation\": \"5.3\"}\n", string(content))
+
+	renamed := filepath.Join(dir, "Other.uproject")
+	assert.NoError(t, plan.Rename(game, renamed, "rename"))
+	assert.Error(t, plan.Create(ini, nil, "exists"))
+
+	assert.Equal(t, `--- a/`+filepath.ToSlash(game)+`
++++ /dev/null
+@@ -1,1 +0,0 @@
+-{"EngineAssociation": "5.2"}
+--- /dev/null
++++ b/`+filepath.ToSlash(renamed)+`
+@@ -0,0 +1,1 @@
++{"EngineAssociation": "5.3"}
+`, plan.Diff())
+
+	assert.NoError(t, plan.Apply())
+	_, err = os.Stat(game)
+	assert.True(t, os.IsNotExist(err))
+	data, err := ioutil.ReadFile(renamed)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"EngineAssociation\": \"5.3\"}\n", string(data))
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	assert.Empty(t, leftovers)
+}
+
+func TestPlan_ApplyModifiedConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "DefaultGame.ini")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("ProjectVersion=1.0.0\n"), 0644))
+
+	plan := NewPlan()
+	assert.NoError(t, plan.Update(path, []byte("ProjectVersion=2.0.0\n"), "version"))
+	assert.NoError(t, ioutil.WriteFile(path, []byte("ProjectVersion=1.5.0\n"), 0644))
+
+	assert.Error(t, plan.Apply())
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ProjectVersion=1.5.0\n", string(data))
+}
+
+func TestPlan_Edit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Config", "DefaultGame.ini")
+	appendLine := func(line string) func([]byte) ([]byte, error) {
+		return func(content []byte) ([]byte, error) {
+			return append(content, line...), nil
+		}
+	}
+
+	plan := NewPlan()
+	assert.NoError(t, plan.Edit(path, "version", appendLine("ProjectVersion=1.0.0\n")))
+	assert.NoError(t, plan.Edit(path, "name", appendLine("ProjectName=Game\n")))
+	changes := plan.Changes()
+	assert.Len(t, changes, 1)
+	assert.True(t, changes[0].Created())
+	assert.Equal(t, []string{"version", "name"}, changes[0].Reasons)
+
+	assert.NoError(t, plan.Apply())
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ProjectVersion=1.0.0\nProjectName=Game\n", string(data))
+}
@@ -0,0 +1,105 @@
+package engines
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/jsonedit"
+	"github.com/pkg/errors"
+)
+
+// BuildVersion is the content of Engine/Build/Build.version
+type BuildVersion struct {
+	MajorVersion         int    `json:"MajorVersion"`
+	MinorVersion         int    `json:"MinorVersion"`
+	PatchVersion         int    `json:"PatchVersion"`
+	Changelist           int    `json:"Changelist"`
+	CompatibleChangelist int    `json:"CompatibleChangelist"`
+	IsLicenseeVersion    int    `json:"IsLicenseeVersion"`
+	IsPromotedBuild      int    `json:"IsPromotedBuild"`
+	BranchName           string `json:"BranchName"`
+}
+
+// BuildVersionPath returns the location of Build.version for an engine root directory
+func BuildVersionPath(engineDir string) string {
+	return filepath.Join(engineDir, "Engine", "Build", "Build.version")
+}
+
+// ReadBuildVersion reads the Build.version of the engine installed in engineDir
+func ReadBuildVersion(engineDir string) (*BuildVersion, error) {
+	path := BuildVersionPath(engineDir)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return ParseBuildVersion(data)
+}
+
+// ParseBuildVersion parses the content of a Build.version file
+func ParseBuildVersion(data []byte) (*BuildVersion, error) {
+	answer := &BuildVersion{}
+	if err := json.Unmarshal(data, answer); err != nil {
+		return nil, errors.Wrap(err, "parsing Build.version")
+	}
+	return answer, nil
+}
+
+// String returns the version as Major.Minor.Patch
+func (v *BuildVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.MajorVersion, v.MinorVersion, v.PatchVersion)
+}
+
+// BuildVersion reads the version of the installed engine
+func (i *Installation) BuildVersion() (*BuildVersion, error) {
+	return ReadBuildVersion(i.Path)
+}
+
+// Check returns an error if the numbers contradict each other. A CompatibleChangelist newer than the
+// Changelist makes the engine refuse its own binaries. A Changelist of 0, as in source builds, isn't
+// compared.
+func (v *BuildVersion) Check() error {
+	for _, field := range []struct {
+		name  string
+		value int
+	}{
+		{"MajorVersion", v.MajorVersion},
+		{"MinorVersion", v.MinorVersion},
+		{"PatchVersion", v.PatchVersion},
+		{"Changelist", v.Changelist},
+		{"CompatibleChangelist", v.CompatibleChangelist},
+	} {
+		if field.value < 0 {
+			return errors.Errorf("%s is negative: %d", field.name, field.value)
+		}
+	}
+	if v.Changelist > 0 && v.CompatibleChangelist > v.Changelist {
+		return errors.Errorf("CompatibleChangelist %d is greater than Changelist %d", v.CompatibleChangelist, v.Changelist)
+	}
+	return nil
+}
+
+// SetBuildVersion writes every field of v to the content of a Build.version file, keeping its formatting
+// and any fields it doesn't know
+func SetBuildVersion(data []byte, v *BuildVersion) ([]byte, error) {
+	for _, field := range []struct {
+		key   string
+		value interface{}
+	}{
+		{"MajorVersion", v.MajorVersion},
+		{"MinorVersion", v.MinorVersion},
+		{"PatchVersion", v.PatchVersion},
+		{"Changelist", v.Changelist},
+		{"CompatibleChangelist", v.CompatibleChangelist},
+		{"IsLicenseeVersion", v.IsLicenseeVersion},
+		{"IsPromotedBuild", v.IsPromotedBuild},
+		{"BranchName", v.BranchName},
+	} {
+		var err error
+		if data, err = jsonedit.Set(data, field.key, field.value); err != nil {
+			return nil, errors.Wrapf(err, "setting %s", field.key)
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,80 @@
+package engines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBuildVersion(t *testing.T) {
+	got, err := ParseBuildVersion([]byte(`{
+	"MajorVersion": 5,
+	"MinorVersion": 3,
+	"PatchVersion": 2,
+	"Changelist": 29314046,
+	"CompatibleChangelist": 27405482,
+	"IsLicenseeVersion": 0,
+	"IsPromotedBuild": 1,
+	"BranchName": "++UE5+Release-5.3"
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &BuildVersion{
+		MajorVersion:         5,
+		MinorVersion:         3,
+		PatchVersion:         2,
+		Changelist:           29314046,
+		CompatibleChangelist: 27405482,
+		IsPromotedBuild:      1,
+		BranchName:           "++UE5+Release-5.3",
+	}, got)
+	assert.Equal(t, "5.3.2", got.String())
+
+	_, err = ParseBuildVersion([]byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestBuildVersion_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		version BuildVersion
+		wantErr bool
+	}{
+		{"Promoted", BuildVersion{MajorVersion: 5, MinorVersion: 3, Changelist: 29314046, CompatibleChangelist: 27405482}, false},
+		{"Same changelist", BuildVersion{MajorVersion: 5, Changelist: 100, CompatibleChangelist: 100}, false},
+		{"Source build", BuildVersion{MajorVersion: 5, CompatibleChangelist: 27405482}, false},
+		{"Compatible after changelist", BuildVersion{MajorVersion: 5, Changelist: 100, CompatibleChangelist: 101}, true},
+		{"Negative", BuildVersion{MajorVersion: 5, PatchVersion: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.version.Check() != nil)
+		})
+	}
+}
+
+func TestSetBuildVersion(t *testing.T) {
+	data := []byte(`{
+	"MajorVersion": 5,
+	"MinorVersion": 3,
+	"PatchVersion": 2,
+	"Changelist": 0,
+	"CompatibleChangelist": 27405482,
+	"IsLicenseeVersion": 0,
+	"IsPromotedBuild": 0,
+	"BranchName": "++UE5+Release-5.3"
+}
+`)
+	got, err := SetBuildVersion(data, &BuildVersion{MajorVersion: 5, MinorVersion: 4, Changelist: 120, CompatibleChangelist: 120, IsLicenseeVersion: 1, BranchName: "++Studio+Main"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+	"MajorVersion": 5,
+	"MinorVersion": 4,
+	"PatchVersion": 0,
+	"Changelist": 120,
+	"CompatibleChangelist": 120,
+	"IsLicenseeVersion": 1,
+	"IsPromotedBuild": 0,
+	"BranchName": "++Studio+Main"
+}
+`, string(got))
+}
@@ -0,0 +1,141 @@
+package engines
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SourceLauncher marks engines installed through the Epic Games Launcher
+	SourceLauncher = "launcher"
+	// SourceInstallIni marks engines registered in the Install.ini used on Linux and Mac
+	SourceInstallIni = "Install.ini"
+	// SourceRegistry marks engines registered in the windows registry
+	SourceRegistry = "registry"
+)
+
+var (
+	guidRegex    = regexp.MustCompile(`^\{?[0-9A-Fa-f]{8}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{4}-?[0-9A-Fa-f]{12}\}?$`)
+	versionRegex = regexp.MustCompile(`^\d+\.\d+$`)
+)
+
+var ( // For Test Mocks
+	registeredInstallations = platformInstallations
+	launcherInstalledPath   = defaultLauncherInstalledPath
+)
+
+// Installation is an engine known to this machine
+type Installation struct {
+	// Identifier is the value a .uproject uses in EngineAssociation, either a version such as 5.3
+	// for launcher installs or a GUID for source builds
+	Identifier string
+	Path       string
+	Source     string
+}
+
+// Installations returns every engine registered on this machine sorted by identifier
+func Installations() ([]Installation, error) {
+	answer, err := registeredInstallations()
+	if err != nil {
+		return nil, err
+	}
+	launcher, err := launcherInstallations()
+	if err != nil {
+		return nil, err
+	}
+	answer = append(answer, launcher...)
+	sort.SliceStable(answer, func(i, j int) bool {
+		return answer[i].Identifier < answer[j].Identifier
+	})
+	return answer, nil
+}
+
+// Find returns the installation matching the identifier, GUIDs match regardless of case and braces
+func Find(installations []Installation, identifier string) (*Installation, bool) {
+	for i := range installations {
+		if SameIdentifier(installations[i].Identifier, identifier) {
+			return &installations[i], true
+		}
+	}
+	return nil, false
+}
+
+// SameIdentifier compares two engine identifiers the way the engine does
+func SameIdentifier(a, b string) bool {
+	if IsGUID(a) && IsGUID(b) {
+		return normalizeGUID(a) == normalizeGUID(b)
+	}
+	return strings.EqualFold(a, b)
+}
+
+// IsGUID returns true if the identifier is a source build GUID
+func IsGUID(identifier string) bool {
+	return guidRegex.MatchString(identifier)
+}
+
+// IsVersion returns true if the identifier is a launcher engine version such as 5.3
+func IsVersion(identifier string) bool {
+	return versionRegex.MatchString(identifier)
+}
+
+// ValidateIdentifier checks the identifier is either an engine version or a GUID
+func ValidateIdentifier(identifier string) error {
+	if IsVersion(identifier) || IsGUID(identifier) {
+		return nil
+	}
+	return errors.Errorf("invalid engine identifier %q, expected a version such as 5.3 or a GUID", identifier)
+}
+
+func normalizeGUID(guid string) string {
+	return strings.ToUpper(strings.Replace(strings.Trim(guid, "{}"), "-", "", -1))
+}
+
+// launcherInstalled is the format of LauncherInstalled.dat
+type launcherInstalled struct {
+	InstallationList []struct {
+		InstallLocation string `json:"InstallLocation"`
+		AppName         string `json:"AppName"`
+		AppVersion      string `json:"AppVersion"`
+	} `json:"InstallationList"`
+}
+
+func launcherInstallations() ([]Installation, error) {
+	path := launcherInstalledPath()
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return parseLauncherInstalled(data)
+}
+
+// parseLauncherInstalled extracts the engines from the launcher's list of installed apps
+func parseLauncherInstalled(data []byte) ([]Installation, error) {
+	installed := &launcherInstalled{}
+	if err := json.Unmarshal(data, installed); err != nil {
+		return nil, errors.Wrap(err, "parsing LauncherInstalled.dat")
+	}
+	var answer []Installation
+	for _, app := range installed.InstallationList {
+		if !strings.HasPrefix(app.AppName, "UE_") {
+			continue
+		}
+		answer = append(answer, Installation{
+			Identifier: strings.TrimPrefix(app.AppName, "UE_"),
+			Path:       app.InstallLocation,
+			Source:     SourceLauncher,
+		})
+	}
+	return answer, nil
+}
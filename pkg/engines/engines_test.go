@@ -0,0 +1,73 @@
+package engines
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const launcherData = `{
+	"InstallationList": [
+		{
+			"InstallLocation": "C:\\Program Files\\Epic Games\\UE_5.3",
+			"NamespaceId": "ue",
+			"ItemId": "abc",
+			"ArtifactId": "UE_5.3",
+			"AppVersion": "5.3.2-29314046+++UE5+Release-5.3-Windows",
+			"AppName": "UE_5.3"
+		},
+		{
+			"InstallLocation": "C:\\Program Files\\Epic Games\\Fortnite",
+			"AppName": "Fortnite"
+		}
+	]
+}`
+
+func TestInstallations(t *testing.T) {
+	t.Cleanup(func() {
+		registeredInstallations = platformInstallations
+		launcherInstalledPath = defaultLauncherInstalledPath
+	})
+	dat := filepath.Join(t.TempDir(), "LauncherInstalled.dat")
+	assert.NoError(t, ioutil.WriteFile(dat, []byte(launcherData), 0644))
+	launcherInstalledPath = func() string { return dat }
+	registeredInstallations = func() ([]Installation, error) {
+		return []Installation{{Identifier: "{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", Path: "/engines/custom", Source: SourceInstallIni}}, nil
+	}
+
+	got, err := Installations()
+	assert.NoError(t, err)
+	assert.Equal(t, []Installation{
+		{Identifier: "5.3", Path: `C:\Program Files\Epic Games\UE_5.3`, Source: SourceLauncher},
+		{Identifier: "{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", Path: "/engines/custom", Source: SourceInstallIni},
+	}, got)
+
+	found, ok := Find(got, "2f6e3e0b4f7a4e0b9b4e1c1d2e3f4a5b")
+	assert.True(t, ok)
+	assert.Equal(t, "/engines/custom", found.Path)
+
+	_, ok = Find(got, "5.2")
+	assert.False(t, ok)
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		wantErr    bool
+	}{
+		{"Version", "5.3", false},
+		{"GUID braces", "{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", false},
+		{"GUID plain", "2F6E3E0B4F7A4E0B9B4E1C1D2E3F4A5B", false},
+		{"Full version", "5.3.2", true},
+		{"Garbage", "latest", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIdentifier(tt.identifier)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
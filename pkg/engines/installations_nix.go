@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+package engines
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+// InstallationsSection is the Install.ini section listing source built engines
+const InstallationsSection = "Installations"
+
+var installIniPath = defaultInstallIniPath // For Test Mocks
+
+// defaultInstallIniPath returns the location of the Install.ini the engine uses to find source builds
+func defaultInstallIniPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(home, "Library", "Application Support", "Epic", "UnrealEngine", "Install.ini")
+	}
+	return filepath.Join(home, ".config", "Epic", "UnrealEngine", "Install.ini")
+}
+
+func defaultLauncherInstalledPath() string {
+	if runtime.GOOS != "darwin" {
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "Library", "Application Support", "Epic", "UnrealEngineLauncher", "LauncherInstalled.dat")
+}
+
+func platformInstallations() ([]Installation, error) {
+	path := installIniPath()
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	cfg, err := ueini.Load(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s", path)
+	}
+	var answer []Installation
+	for _, key := range cfg.Section(InstallationsSection).Keys() {
+		answer = append(answer, Installation{
+			Identifier: key.Name(),
+			Path:       key.String(),
+			Source:     SourceInstallIni,
+		})
+	}
+	return answer, nil
+}
+
+func writeInstallation(identifier string, engineDir string) error {
+	path := installIniPath()
+	if path == "" {
+		return errors.New("unable to find the home directory for Install.ini")
+	}
+	cfg, err := ueini.LoadOrEmpty(path)
+	if err != nil {
+		return err
+	}
+	cfg.Section(InstallationsSection).Key(identifier).SetValue(engineDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "creating %s", filepath.Dir(path))
+	}
+	return ueini.Save(cfg, path)
+}
+
+func deleteInstallation(identifier string) error {
+	path := installIniPath()
+	cfg, err := ueini.LoadOrEmpty(path)
+	if err != nil {
+		return err
+	}
+	cfg.Section(InstallationsSection).DeleteKey(identifier)
+	return ueini.Save(cfg, path)
+}
@@ -0,0 +1,73 @@
+//go:build !windows
+// +build !windows
+
+package engines
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformInstallations(t *testing.T) {
+	t.Cleanup(func() {
+		installIniPath = defaultInstallIniPath
+	})
+	path := filepath.Join(t.TempDir(), "Install.ini")
+	installIniPath = func() string { return path }
+
+	got, err := platformInstallations()
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("[Installations]\n{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}=/home/build/UnrealEngine\n"), 0644))
+	got, err = platformInstallations()
+	assert.NoError(t, err)
+	assert.Equal(t, []Installation{
+		{Identifier: "{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", Path: "/home/build/UnrealEngine", Source: SourceInstallIni},
+	}, got)
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	t.Cleanup(func() {
+		installIniPath = defaultInstallIniPath
+	})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Epic", "UnrealEngine", "Install.ini")
+	installIniPath = func() string { return path }
+
+	engineDir := filepath.Join(dir, "UnrealEngine")
+	_, err := Register(engineDir)
+	assert.Error(t, err, "engine without Build.version")
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(BuildVersionPath(engineDir)), 0755))
+	assert.NoError(t, ioutil.WriteFile(BuildVersionPath(engineDir), []byte(`{"MajorVersion": 5, "MinorVersion": 3, "PatchVersion": 2}`), 0644))
+
+	identifier, err := Register(engineDir)
+	assert.NoError(t, err)
+	assert.True(t, IsGUID(identifier))
+
+	again, err := Register(engineDir + "/")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier, again)
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "[Installations]\n"+identifier+"="+engineDir+"\n", string(data))
+
+	installations, err := platformInstallations()
+	assert.NoError(t, err)
+	version, err := installations[0].BuildVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "5.3.2", version.String())
+
+	removed, err := Unregister(engineDir)
+	assert.NoError(t, err)
+	assert.Equal(t, identifier, removed.Identifier)
+
+	_, err = Unregister(identifier)
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+//go:build windows
+// +build windows
+
+package engines
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows/registry"
+)
+
+// BuildsKey is the registry key listing source built engines
+const BuildsKey = `SOFTWARE\Epic Games\Unreal Engine\Builds`
+
+func defaultLauncherInstalledPath() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		programData = `C:\ProgramData`
+	}
+	return filepath.Join(programData, "Epic", "UnrealEngineLauncher", "LauncherInstalled.dat")
+}
+
+func platformInstallations() ([]Installation, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, BuildsKey, registry.QUERY_VALUE)
+	if err != nil {
+		if err == registry.ErrNotExist {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "opening registry key %s", BuildsKey)
+	}
+	defer key.Close()
+
+	names, err := key.ReadValueNames(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading registry key %s", BuildsKey)
+	}
+	var answer []Installation
+	for _, name := range names {
+		path, _, err := key.GetStringValue(name)
+		if err != nil {
+			continue
+		}
+		answer = append(answer, Installation{
+			Identifier: name,
+			Path:       path,
+			Source:     SourceRegistry,
+		})
+	}
+	return answer, nil
+}
+
+func writeInstallation(identifier string, engineDir string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, BuildsKey, registry.SET_VALUE)
+	if err != nil {
+		return errors.Wrapf(err, "opening registry key %s", BuildsKey)
+	}
+	defer key.Close()
+	return key.SetStringValue(identifier, engineDir)
+}
+
+func deleteInstallation(identifier string) error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, BuildsKey, registry.SET_VALUE)
+	if err != nil {
+		return errors.Wrapf(err, "opening registry key %s", BuildsKey)
+	}
+	defer key.Close()
+	return key.DeleteValue(identifier)
+}
@@ -0,0 +1,68 @@
+package engines
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/pkg/errors"
+)
+
+// Register adds a source built engine to this machine's registered engines and returns its identifier.
+// Registering an engine that is already known returns the existing identifier.
+func Register(engineDir string) (string, error) {
+	engineDir, err := filepath.Abs(engineDir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(BuildVersionPath(engineDir)); err != nil {
+		return "", errors.Errorf("%s does not look like an engine, %s is missing", engineDir, BuildVersionPath(engineDir))
+	}
+	installations, err := registeredInstallations()
+	if err != nil {
+		return "", err
+	}
+	for _, installation := range installations {
+		if samePath(installation.Path, engineDir) {
+			return installation.Identifier, nil
+		}
+	}
+	guid, err := utils.NewGUID()
+	if err != nil {
+		return "", errors.Wrap(err, "generating engine identifier")
+	}
+	identifier := utils.GUIDWithBraces(guid)
+	if err := writeInstallation(identifier, engineDir); err != nil {
+		return "", err
+	}
+	return identifier, nil
+}
+
+// Unregister removes a source built engine by identifier or path and returns the removed installation
+func Unregister(identifierOrPath string) (*Installation, error) {
+	installations, err := registeredInstallations()
+	if err != nil {
+		return nil, err
+	}
+	installation, ok := Find(installations, identifierOrPath)
+	if !ok {
+		abs, _ := filepath.Abs(identifierOrPath)
+		for i := range installations {
+			if samePath(installations[i].Path, abs) {
+				installation, ok = &installations[i], true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, errors.Errorf("no registered engine matches %s", identifierOrPath)
+	}
+	if err := deleteInstallation(installation.Identifier); err != nil {
+		return nil, err
+	}
+	return installation, nil
+}
+
+func samePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
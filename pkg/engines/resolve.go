@@ -0,0 +1,110 @@
+package engines
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Resolve returns the concrete version of the engine an EngineAssociation refers to. Registered engines
+// are read from their Build.version, unregistered launcher versions such as 5.3 are used as is and an
+// empty association means the project lives inside the engine's own folder.
+func Resolve(association string, projectDir string) (*BuildVersion, error) {
+	if association == "" {
+		engineDir, err := findEnclosingEngine(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		return ReadBuildVersion(engineDir)
+	}
+	installations, err := Installations()
+	if err != nil {
+		return nil, err
+	}
+	if installation, ok := Find(installations, association); ok {
+		version, err := installation.BuildVersion()
+		if err == nil || !IsVersion(association) {
+			return version, err
+		}
+	}
+	if IsVersion(association) {
+		return ParseEngineVersion(association)
+	}
+	return nil, errors.Errorf("engine %s is not registered on this machine", association)
+}
+
+// ParseEngineVersion parses a version such as 5.3, 5.3.2 or 5.3.2-29314046+++UE5+Release-5.3
+func ParseEngineVersion(s string) (*BuildVersion, error) {
+	numbers := s
+	if i := strings.IndexAny(numbers, "-+"); i >= 0 {
+		numbers = numbers[:i]
+	}
+	parts := strings.Split(numbers, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, errors.Errorf("invalid engine version %q, expected Major.Minor[.Patch]", s)
+	}
+	var values [3]int
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return nil, errors.Errorf("invalid engine version %q, %q is not a number", s, part)
+		}
+		values[i] = value
+	}
+	return &BuildVersion{MajorVersion: values[0], MinorVersion: values[1], PatchVersion: values[2]}, nil
+}
+
+// CompareMajorMinor compares the major and minor versions, which is what decides whether binaries built
+// against one engine can load in the other. It returns -1, 0 or 1.
+func (v *BuildVersion) CompareMajorMinor(other *BuildVersion) int {
+	switch {
+	case v.MajorVersion != other.MajorVersion:
+		return sign(v.MajorVersion - other.MajorVersion)
+	default:
+		return sign(v.MinorVersion - other.MinorVersion)
+	}
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
+func findEnclosingEngine(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(BuildVersionPath(current)); err == nil {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", errors.Errorf("the project has no EngineAssociation and %s is not inside an engine", dir)
+		}
+	}
+}
+
+// ResolveDir returns the root folder of the engine an EngineAssociation refers to. Unlike Resolve a
+// launcher version must be installed, there is no folder to fall back to.
+func ResolveDir(association string, projectDir string) (string, error) {
+	if association == "" {
+		return findEnclosingEngine(projectDir)
+	}
+	installations, err := Installations()
+	if err != nil {
+		return "", err
+	}
+	if installation, ok := Find(installations, association); ok {
+		return installation.Path, nil
+	}
+	return "", errors.Errorf("engine %s is not installed on this machine", association)
+}
@@ -0,0 +1,85 @@
+package engines
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEngineVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr bool
+	}{
+		{"Major Minor", "5.3", "5.3.0", false},
+		{"Patch", "5.3.2", "5.3.2", false},
+		{"Full", "5.3.2-29314046+++UE5+Release-5.3", "5.3.2", false},
+		{"Single", "5", "", true},
+		{"Words", "five.three", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEngineVersion(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestCompareMajorMinor(t *testing.T) {
+	v53 := &BuildVersion{MajorVersion: 5, MinorVersion: 3, PatchVersion: 2}
+	assert.Equal(t, 0, v53.CompareMajorMinor(&BuildVersion{MajorVersion: 5, MinorVersion: 3}))
+	assert.Equal(t, 1, v53.CompareMajorMinor(&BuildVersion{MajorVersion: 5, MinorVersion: 2, PatchVersion: 9}))
+	assert.Equal(t, -1, v53.CompareMajorMinor(&BuildVersion{MajorVersion: 5, MinorVersion: 4}))
+	assert.Equal(t, 1, v53.CompareMajorMinor(&BuildVersion{MajorVersion: 4, MinorVersion: 27}))
+}
+
+func TestResolve(t *testing.T) {
+	t.Cleanup(func() {
+		registeredInstallations = platformInstallations
+		launcherInstalledPath = defaultLauncherInstalledPath
+	})
+	dir := t.TempDir()
+	engineDir := filepath.Join(dir, "Engine53")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(BuildVersionPath(engineDir)), 0755))
+	assert.NoError(t, ioutil.WriteFile(BuildVersionPath(engineDir), []byte(`{"MajorVersion": 5, "MinorVersion": 3, "PatchVersion": 2}`), 0644))
+	launcherInstalledPath = func() string { return "" }
+	registeredInstallations = func() ([]Installation, error) {
+		return []Installation{{Identifier: "{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", Path: engineDir}}, nil
+	}
+
+	got, err := Resolve("{2F6E3E0B-4F7A-4E0B-9B4E-1C1D2E3F4A5B}", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.3.2", got.String())
+
+	got, err = Resolve("5.1", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.1.0", got.String())
+
+	projectDir := filepath.Join(engineDir, "Samples", "Game")
+	assert.NoError(t, os.MkdirAll(projectDir, 0755))
+	got, err = Resolve("", projectDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.3.2", got.String())
+
+	_, err = Resolve("{00000000-0000-0000-0000-000000000000}", dir)
+	assert.Error(t, err)
+
+	engineRoot, err := ResolveDir("{2f6e3e0b-4f7a-4e0b-9b4e-1c1d2e3f4a5b}", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, engineDir, engineRoot)
+	engineRoot, err = ResolveDir("", projectDir)
+	assert.NoError(t, err)
+	assert.Equal(t, engineDir, engineRoot)
+	_, err = ResolveDir("5.1", dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package engines
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// versionMacros are the macros of Version.h holding the engine version, in order
+var versionMacros = []string{"ENGINE_MAJOR_VERSION", "ENGINE_MINOR_VERSION", "ENGINE_PATCH_VERSION"}
+
+// VersionHeaderPath returns the location of the header compiling the version into the engine for an
+// engine root directory
+func VersionHeaderPath(engineDir string) string {
+	return filepath.Join(engineDir, "Engine", "Source", "Runtime", "Launch", "Resources", "Version.h")
+}
+
+func macroRegex(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^([ \t]*#[ \t]*define[ \t]+` + name + `[ \t]+)(\d+)`)
+}
+
+// ParseVersionHeader reads the ENGINE_MAJOR_VERSION, ENGINE_MINOR_VERSION and ENGINE_PATCH_VERSION
+// macros of Version.h
+func ParseVersionHeader(data []byte) ([3]int, error) {
+	var answer [3]int
+	for i, name := range versionMacros {
+		m := macroRegex(name).FindSubmatch(data)
+		if m == nil {
+			return answer, errors.Errorf("Version.h doesn't define %s", name)
+		}
+		n, err := strconv.Atoi(string(m[2]))
+		if err != nil {
+			return answer, errors.Wrapf(err, "parsing %s", name)
+		}
+		answer[i] = n
+	}
+	return answer, nil
+}
+
+// SetVersionHeader writes major, minor and patch to the macros of Version.h, leaving everything else
+// including the alignment of the values as it is
+func SetVersionHeader(data []byte, version [3]int) ([]byte, error) {
+	for i, name := range versionMacros {
+		re := macroRegex(name)
+		if !re.Match(data) {
+			return nil, errors.Errorf("Version.h doesn't define %s", name)
+		}
+		data = re.ReplaceAll(data, []byte("${1}"+strconv.Itoa(version[i])))
+	}
+	return data, nil
+}
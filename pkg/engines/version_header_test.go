@@ -0,0 +1,45 @@
+package engines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const versionHeader = `// Copyright Epic Games, Inc. All Rights Reserved.
+
+#pragma once
+
+#define ENGINE_MAJOR_VERSION	5
+#define ENGINE_MINOR_VERSION	3
+#define ENGINE_PATCH_VERSION	2
+
+#define ENGINE_IS_LICENSEE_VERSION 0
+`
+
+func TestParseVersionHeader(t *testing.T) {
+	got, err := ParseVersionHeader([]byte(versionHeader))
+	assert.NoError(t, err)
+	assert.Equal(t, [3]int{5, 3, 2}, got)
+
+	_, err = ParseVersionHeader([]byte("#define ENGINE_MAJOR_VERSION 5\n"))
+	assert.Error(t, err)
+}
+
+func TestSetVersionHeader(t *testing.T) {
+	got, err := SetVersionHeader([]byte(versionHeader), [3]int{5, 4, 10})
+	assert.NoError(t, err)
+	assert.Equal(t, `// Copyright Epic Games, Inc. All Rights Reserved.
+
+#pragma once
+
+#define ENGINE_MAJOR_VERSION	5
+#define ENGINE_MINOR_VERSION	4
+#define ENGINE_PATCH_VERSION	10
+
+#define ENGINE_IS_LICENSEE_VERSION 0
+`, string(got))
+
+	_, err = SetVersionHeader([]byte("#pragma once\n"), [3]int{5, 4, 0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package filetarget
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/jsonedit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/tomledit"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatJSON edits the file with jsonedit
+	FormatJSON = "json"
+	// FormatTOML edits the file with tomledit
+	FormatTOML = "toml"
+
+	// DefaultTemplate is the template used when a target has none
+	DefaultTemplate = "{{.Version}}"
+)
+
+// Formats are the file formats that can be targeted
+var Formats = []string{FormatJSON, FormatTOML}
+
+// Format returns the format of a file, the one configured or else the one of its extension
+func Format(target config.FileTarget, path string) (string, error) {
+	format := strings.ToLower(target.Format)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	for _, f := range Formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", errors.Errorf("can't tell the format of %s, set format to one of %s", path, strings.Join(Formats, ", "))
+}
+
+// Get returns the value at path, strings are unquoted and anything else is returned as written
+func Get(format string, content []byte, path string) (string, error) {
+	if format == FormatTOML {
+		return tomledit.GetString(content, path)
+	}
+	return jsonedit.GetString(content, path)
+}
+
+// Set replaces the value at path keeping everything else in the file as it is. The value must already
+// exist and keeps its type, so a version written to a number must be a number.
+func Set(format string, content []byte, path string, value string) ([]byte, error) {
+	if format == FormatTOML {
+		return tomledit.Set(content, path, value)
+	}
+	root, err := jsonedit.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	node, err := root.Lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	switch node.Kind {
+	case jsonedit.KindString:
+		return jsonedit.Set(content, path, value)
+	case jsonedit.KindNumber:
+		var number json.Number
+		if err := json.Unmarshal([]byte(value), &number); err != nil {
+			return nil, errors.Errorf("%s is a number, %q is not", path, value)
+		}
+		return jsonedit.SetRaw(content, path, []byte(value))
+	case jsonedit.KindBool:
+		if value != "true" && value != "false" {
+			return nil, errors.Errorf("%s is a bool, %q is not", path, value)
+		}
+		return jsonedit.SetRaw(content, path, []byte(value))
+	}
+	return nil, errors.Errorf("%s is not a string, number or bool", path)
+}
+
+// Plan renders every target and adds the edits of the files they match to the plan. Files are relative
+// to root, the folder holding .uvu. A target that matches no file is an error, the same as a path that
+// doesn't exist in a file.
+func Plan(plan *edit.Plan, root string, targets []config.FileTarget, data stamp.Data) error {
+	for i, target := range targets {
+		text := target.Template
+		if text == "" {
+			text = DefaultTemplate
+		}
+		err := stamp.PlanFiles(plan, root, target.File, text, data, target.Path, func(path string, content []byte, value string) ([]byte, error) {
+			format, err := Format(target, path)
+			if err != nil {
+				return nil, err
+			}
+			return Set(format, content, target.Path, value)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "file target %d", i+1)
+		}
+	}
+	return nil
+}
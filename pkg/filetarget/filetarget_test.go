@@ -0,0 +1,118 @@
+package filetarget
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/stretchr/testify/assert"
+)
+
+const packageJSON = `{
+  "name": "companion",
+  "version": "1.0.0",
+  "private": true,
+  "build": {"number": 41}
+}
+`
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		path    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"JSON string", FormatJSON, packageJSON, "$.version", "1.2.0", `{
+  "name": "companion",
+  "version": "1.2.0",
+  "private": true,
+  "build": {"number": 41}
+}
+`, false},
+		{"JSON number", FormatJSON, packageJSON, "build.number", "42", `{
+  "name": "companion",
+  "version": "1.0.0",
+  "private": true,
+  "build": {"number": 42}
+}
+`, false},
+		{"JSON number mismatch", FormatJSON, packageJSON, "build.number", "1.2.0", "", true},
+		{"JSON bool mismatch", FormatJSON, packageJSON, "private", "1.2.0", "", true},
+		{"JSON object", FormatJSON, packageJSON, "build", "1.2.0", "", true},
+		{"JSON missing", FormatJSON, packageJSON, "$.release", "1.2.0", "", true},
+		{"TOML", FormatTOML, "[package]\nversion = \"1.0.0\"\n", "package.version", "1.2.0", "[package]\nversion = \"1.2.0\"\n", false},
+		{"TOML missing", FormatTOML, "[package]\nversion = \"1.0.0\"\n", "version", "1.2.0", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Set(tt.format, []byte(tt.content), tt.path, tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			if err == nil {
+				assert.Equal(t, tt.want, string(got))
+				value, err := Get(tt.format, got, tt.path)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.value, value)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	format, err := Format(config.FileTarget{}, "Web/package.JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+	format, err = Format(config.FileTarget{Format: "toml"}, "Tools/manifest.cfg")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatTOML, format)
+	_, err = Format(config.FileTarget{}, "Tools/manifest.cfg")
+	assert.Error(t, err)
+}
+
+func TestPlan(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"Web/package.json":   packageJSON,
+		"Tools/manifest.cfg": "# build\n[build]\nversion = '1.0.0'\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	targets := []config.FileTarget{
+		{File: "Web/package.json", Path: "$.version"},
+		{File: "Web/*.json", Path: "build.number", Template: "{{.BuildNumber}}"},
+		{File: "Tools/manifest.cfg", Format: "toml", Path: "build.version", Template: "{{.Version}}+{{.GitSHA}}"},
+	}
+	data := stamp.Data{Version: "1.2.0", BuildNumber: "42", GitSHA: "abc1234"}
+
+	plan := edit.NewPlan()
+	assert.NoError(t, Plan(plan, root, targets, data))
+	assert.Len(t, plan.Changes(), 2)
+	assert.NoError(t, plan.Apply())
+
+	got, err := ioutil.ReadFile(filepath.Join(root, "Web", "package.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), `"version": "1.2.0",`)
+	assert.Contains(t, string(got), `"build": {"number": 42}`)
+	got, err = ioutil.ReadFile(filepath.Join(root, "Tools", "manifest.cfg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# build\n[build]\nversion = '1.2.0+abc1234'\n", string(got))
+
+	for _, bad := range []config.FileTarget{
+		{File: "Missing/package.json", Path: "$.version"},
+		{File: "Web/package.json", Path: "$.release"},
+		{File: "Tools/manifest.cfg", Path: "build.version"},
+		{File: "Web/package.json", Path: "$.version", Template: "{{.Missing}}"},
+	} {
+		assert.Error(t, Plan(edit.NewPlan(), root, []config.FileTarget{bad}, data), bad.File)
+	}
+}
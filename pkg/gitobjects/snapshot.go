@@ -0,0 +1,207 @@
+package gitobjects
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/pkg/errors"
+)
+
+// regularMode is the tree mode of a file that isn't executable
+const regularMode = "100644"
+
+// file is a blob of the commit a snapshot was taken from
+type file struct {
+	mode    string
+	content []byte
+}
+
+// Change is a file of the snapshot that differs from the commit
+type Change struct {
+	// Path is slash separated and relative to the root of the tree
+	Path string
+	// After is nil when the file was deleted
+	After []byte
+}
+
+// Snapshot holds some of the files of a commit in a temporary folder, so they can be edited like a work
+// tree and committed back with git plumbing. No checkout, index or work tree of the repository is used,
+// so it works on bare repositories.
+type Snapshot struct {
+	GitDir string
+	// Ref is the full name of the branch, such as refs/heads/main
+	Ref string
+	// Parent is what Ref pointed to when the snapshot was taken
+	Parent string
+	// Dir is the temporary folder holding the files, the root of the tree
+	Dir string
+
+	files map[string]file
+}
+
+// Extract writes the files of the commit at the tip of branch ref that include accepts to a temporary
+// folder. Close removes it.
+func Extract(gitDir string, ref string, include func(path string) bool) (*Snapshot, error) {
+	fullRef, err := gitutil.Run(gitDir, "rev-parse", "--symbolic-full-name", ref)
+	if err != nil || !strings.HasPrefix(fullRef, "refs/heads/") {
+		return nil, errors.Errorf("%s is not a branch in %s", ref, gitDir)
+	}
+	commit, err := gitutil.Run(gitDir, "rev-parse", "--verify", "--quiet", fullRef+"^{commit}")
+	if err != nil {
+		return nil, errors.Errorf("%s has no commits", fullRef)
+	}
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a folder for the snapshot")
+	}
+	answer := &Snapshot{GitDir: gitDir, Ref: fullRef, Parent: commit, Dir: dir, files: map[string]file{}}
+	if err := answer.extract(include); err != nil {
+		answer.Close()
+		return nil, err
+	}
+	return answer, nil
+}
+
+// Add writes more files of the commit to the snapshot, such as those named by a config file that was
+// extracted first. Files already in the snapshot keep their edits.
+func (s *Snapshot) Add(include func(path string) bool) error {
+	return s.extract(func(path string) bool {
+		_, ok := s.files[path]
+		return !ok && include(path)
+	})
+}
+
+func (s *Snapshot) extract(include func(path string) bool) error {
+	out, err := gitutil.RunBytes(s.GitDir, nil, "ls-tree", "-r", "-z", "--full-tree", s.Parent)
+	if err != nil {
+		return err
+	}
+	for _, entry := range strings.Split(string(out), "\x00") {
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			continue
+		}
+		fields, name := strings.Fields(entry[:tab]), entry[tab+1:]
+		if len(fields) != 3 || fields[1] != "blob" || !include(name) {
+			continue
+		}
+		content, err := gitutil.RunBytes(s.GitDir, nil, "cat-file", "blob", fields[2])
+		if err != nil {
+			return err
+		}
+		perm := os.FileMode(0644)
+		if fields[0] == "100755" {
+			perm = 0755
+		}
+		local := filepath.Join(s.Dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+			return errors.Wrapf(err, "creating %s", filepath.Dir(local))
+		}
+		if err := ioutil.WriteFile(local, content, perm); err != nil {
+			return errors.Wrapf(err, "writing %s", local)
+		}
+		s.files[name] = file{mode: fields[0], content: content}
+	}
+	return nil
+}
+
+// Changes compares the files in Dir with the commit, sorted by path
+func (s *Snapshot) Changes() ([]Change, error) {
+	var answer []Change
+	seen := map[string]bool{}
+	err := filepath.Walk(s.Dir, func(local string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.Dir, local)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		seen[name] = true
+		content, err := ioutil.ReadFile(local)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", local)
+		}
+		if original, ok := s.files[name]; !ok || !bytes.Equal(original.content, content) {
+			answer = append(answer, Change{Path: name, After: content})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name := range s.files {
+		if !seen[name] {
+			answer = append(answer, Change{Path: name})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Path < answer[j].Path
+	})
+	return answer, nil
+}
+
+// Commit writes the changes as blobs, a tree and a commit on top of Parent and moves Ref to it. The ref
+// is only moved if it still points to Parent, so a concurrent update is never overwritten.
+func (s *Snapshot) Commit(message string) (string, error) {
+	changes, err := s.Changes()
+	if err != nil {
+		return "", err
+	}
+	if len(changes) == 0 {
+		return "", errors.Errorf("nothing changed in %s", s.Ref)
+	}
+	index, err := ioutil.TempDir("", "index")
+	if err != nil {
+		return "", errors.Wrap(err, "creating a temporary index")
+	}
+	defer os.RemoveAll(index)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(index, "index")}
+	if _, err := gitutil.RunEnv(s.GitDir, env, nil, "read-tree", s.Parent); err != nil {
+		return "", err
+	}
+	for _, change := range changes {
+		if change.After == nil {
+			if _, err := gitutil.RunEnv(s.GitDir, env, nil, "update-index", "--force-remove", "--", change.Path); err != nil {
+				return "", err
+			}
+			continue
+		}
+		blob, err := gitutil.RunEnv(s.GitDir, nil, change.After, "hash-object", "-w", "--stdin")
+		if err != nil {
+			return "", err
+		}
+		mode := regularMode
+		if original, ok := s.files[change.Path]; ok {
+			mode = original.mode
+		}
+		info := mode + "," + strings.TrimSpace(string(blob)) + "," + path.Clean(change.Path)
+		if _, err := gitutil.RunEnv(s.GitDir, env, nil, "update-index", "--add", "--cacheinfo", info); err != nil {
+			return "", err
+		}
+	}
+	tree, err := gitutil.RunEnv(s.GitDir, env, nil, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	commit, err := gitutil.Run(s.GitDir, "commit-tree", strings.TrimSpace(string(tree)), "-p", s.Parent, "-m", message)
+	if err != nil {
+		return "", err
+	}
+	if _, err := gitutil.Run(s.GitDir, "update-ref", "-m", message, s.Ref, commit, s.Parent); err != nil {
+		return "", errors.Wrapf(err, "moving %s, it may have been updated by someone else", s.Ref)
+	}
+	return commit, nil
+}
+
+// Close removes the temporary folder
+func (s *Snapshot) Close() {
+	_ = os.RemoveAll(s.Dir)
+}
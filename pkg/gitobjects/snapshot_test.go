@@ -0,0 +1,96 @@
+package gitobjects
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// bareRepo creates a bare repository whose main branch has a config file, a script and some source
+func bareRepo(t *testing.T) string {
+	work := t.TempDir()
+	run := func(args ...string) {
+		_, err := gitutil.Run(work, args...)
+		assert.NoError(t, err)
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	run("config", "commit.gpgsign", "false")
+	for name, content := range map[string]string{
+		"Config/DefaultGame.ini": "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.0.0\n",
+		"Source/Game.cpp":        "// game\n",
+		"Build.sh":               "#!/bin/sh\n",
+	} {
+		path := filepath.Join(work, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	run("add", "-A")
+	run("update-index", "--chmod=+x", "Build.sh")
+	run("commit", "-q", "-m", "initial")
+
+	bare := filepath.Join(t.TempDir(), "game.git")
+	_, err := gitutil.Run(work, "clone", "-q", "--bare", work, bare)
+	assert.NoError(t, err)
+	for _, args := range [][]string{{"config", "user.email", "bot@example.com"}, {"config", "user.name", "Bot"}} {
+		_, err := gitutil.Run(bare, args...)
+		assert.NoError(t, err)
+	}
+	return bare
+}
+
+func TestSnapshot_Commit(t *testing.T) {
+	bare := bareRepo(t)
+	s, err := Extract(bare, "main", func(path string) bool {
+		return strings.HasPrefix(path, "Config/") || path == "Build.sh"
+	})
+	assert.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, "refs/heads/main", s.Ref)
+	assert.NoFileExists(t, filepath.Join(s.Dir, "Source", "Game.cpp"))
+
+	ini := filepath.Join(s.Dir, "Config", "DefaultGame.ini")
+	assert.NoError(t, ioutil.WriteFile(ini, []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.1.0\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(s.Dir, ".uvu"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(s.Dir, ".uvu", "build-number"), []byte("1\n"), 0644))
+	// adding files keeps the edits already made
+	assert.NoError(t, s.Add(func(path string) bool { return path != "Build.sh" }))
+	assert.FileExists(t, filepath.Join(s.Dir, "Source", "Game.cpp"))
+	changes, err := s.Changes()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".uvu/build-number", "Config/DefaultGame.ini"}, []string{changes[0].Path, changes[1].Path})
+
+	commit, err := s.Commit("Set version to 1.1.0")
+	assert.NoError(t, err)
+	head, err := gitutil.Run(bare, "rev-parse", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, commit, head)
+	parent, err := gitutil.Run(bare, "rev-parse", "main^")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Parent, parent)
+
+	content, err := gitutil.Run(bare, "show", "main:Config/DefaultGame.ini")
+	assert.NoError(t, err)
+	assert.Contains(t, content, "ProjectVersion=1.1.0")
+	files, err := gitutil.Run(bare, "ls-tree", "-r", "main")
+	assert.NoError(t, err)
+	assert.Contains(t, files, "100755 blob")
+	assert.Contains(t, files, "Source/Game.cpp")
+	assert.Contains(t, files, ".uvu/build-number")
+
+	// the ref moved since the snapshot was taken
+	_, err = s.Commit("Set version to 1.1.0 again")
+	assert.Error(t, err)
+}
+
+func TestExtract_NotABranch(t *testing.T) {
+	bare := bareRepo(t)
+	_, err := Extract(bare, "nope", func(string) bool { return true })
+	assert.Error(t, err)
+}
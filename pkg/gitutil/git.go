@@ -0,0 +1,219 @@
+package gitutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Run runs git in dir and returns its output without the trailing newline
+func Run(dir string, args ...string) (string, error) {
+	out, err := RunBytes(dir, nil, args...)
+	return strings.TrimRight(string(out), "\r\n"), err
+}
+
+// RunBytes runs git in dir feeding it stdin and returns its raw output
+func RunBytes(dir string, stdin []byte, args ...string) ([]byte, error) {
+	return RunEnv(dir, nil, stdin, args...)
+}
+
+// RunEnv runs git in dir with extra environment variables such as GIT_INDEX_FILE=path
+func RunEnv(dir string, env []string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return stdout.Bytes(), errors.Errorf("git %s: %s", strings.Join(args, " "), message)
+	}
+	return stdout.Bytes(), nil
+}
+
+// IsRepository returns true if dir is inside a git work tree or a bare repository
+func IsRepository(dir string) bool {
+	_, err := Run(dir, "rev-parse", "--git-dir")
+	return err == nil
+}
+
+// Status returns the porcelain status lines of the work tree, empty when there is nothing to commit
+func Status(dir string) ([]string, error) {
+	out, err := Run(dir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// RequireClean returns an error listing the uncommitted changes of the work tree, if there are any
+func RequireClean(dir string) error {
+	if !IsRepository(dir) {
+		return errors.Errorf("%s is not a git repository", dir)
+	}
+	status, err := Status(dir)
+	if err != nil {
+		return err
+	}
+	if len(status) > 0 {
+		return errors.Errorf("there are uncommitted changes, commit or stash them first:\n%s", strings.Join(status, "\n"))
+	}
+	return nil
+}
+
+// ChangedFiles returns the files changed in the work tree since it branched off base, including
+// uncommitted and untracked files. Paths are relative to dir and only files below dir are listed.
+func ChangedFiles(dir string, base string) ([]string, error) {
+	mergeBase, err := Run(dir, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding where HEAD branched off %s", base)
+	}
+	changed, err := Run(dir, "diff", "--name-only", "--relative", mergeBase, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := Run(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return lines(changed + "\n" + untracked), nil
+}
+
+// StagedFiles returns the files added, copied, modified or renamed in the index, relative to dir and
+// below it
+func StagedFiles(dir string) ([]string, error) {
+	out, err := Run(dir, "diff", "--cached", "--name-only", "--relative", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+// ShowStaged returns the content of path, relative to dir, in the index. It is what will be committed
+// even if the work tree has more changes.
+func ShowStaged(dir string, path string) ([]byte, error) {
+	return RunBytes(dir, nil, "show", ":./"+filepath.ToSlash(path))
+}
+
+// Ref is a branch and the commit it points to
+type Ref struct {
+	Name   string
+	Commit string
+	// Remote is true for remote-tracking branches
+	Remote bool
+}
+
+// Branches returns the local branches and, if remotes is true, the remote-tracking branches. Symbolic refs
+// such as origin/HEAD are left out.
+func Branches(dir string, remotes bool) ([]Ref, error) {
+	patterns := []string{"refs/heads"}
+	if remotes {
+		patterns = append(patterns, "refs/remotes")
+	}
+	args := append([]string{"for-each-ref", "--format=%(refname)%09%(refname:short)%09%(objectname)%09%(symref)"}, patterns...)
+	out, err := Run(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+	var answer []Ref
+	for _, line := range lines(out) {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 || (len(fields) > 3 && fields[3] != "") {
+			continue
+		}
+		answer = append(answer, Ref{Name: fields[1], Commit: fields[2], Remote: strings.HasPrefix(fields[0], "refs/remotes/")})
+	}
+	return answer, nil
+}
+
+// MergeBase returns the best common ancestor of two commits, empty if they have none
+func MergeBase(dir string, a string, b string) (string, error) {
+	for _, ref := range []string{a, b} {
+		if _, err := Run(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+			return "", errors.Errorf("%s is not a commit", ref)
+		}
+	}
+	// merge-base fails without output when the histories are unrelated
+	out, err := Run(dir, "merge-base", a, b)
+	if err != nil && out == "" {
+		return "", nil
+	}
+	return out, err
+}
+
+// Show returns the content of path, relative to dir, at ref. It returns false if the file doesn't exist
+// at ref.
+func Show(dir string, ref string, path string) ([]byte, bool, error) {
+	object := ref + ":./" + filepath.ToSlash(path)
+	if _, err := Run(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return nil, false, errors.Errorf("%s is not a commit", ref)
+	}
+	if _, err := Run(dir, "cat-file", "-e", object); err != nil {
+		return nil, false, nil
+	}
+	out, err := RunBytes(dir, nil, "show", object)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+func lines(out string) []string {
+	var answer []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			answer = append(answer, line)
+		}
+	}
+	return answer
+}
+
+// MergeFile merges the changes from base to theirs into ours line by line with git merge-file. It
+// returns the result, with conflict markers labelled ours and theirs, and the number of conflicts.
+func MergeFile(base []byte, ours []byte, theirs []byte) ([]byte, int, error) {
+	dir, err := ioutil.TempDir("", "merge")
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "creating a folder for the merge")
+	}
+	defer os.RemoveAll(dir)
+	var paths []string
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{{"ours", ours}, {"base", base}, {"theirs", theirs}} {
+		path := filepath.Join(dir, file.name)
+		if err := ioutil.WriteFile(path, file.content, 0644); err != nil {
+			return nil, 0, errors.Wrapf(err, "writing %s", path)
+		}
+		paths = append(paths, path)
+	}
+	cmd := exec.Command("git", "merge-file", "-p", "-L", "ours", "-L", "base", "-L", "theirs", paths[0], paths[1], paths[2])
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return stdout.Bytes(), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return nil, 0, errors.Errorf("git merge-file: %s", strings.TrimSpace(stderr.String()+" "+err.Error()))
+	}
+	return stdout.Bytes(), 0, nil
+}
@@ -0,0 +1,161 @@
+package gitutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// initRepo creates a repository with a single committed file
+func initRepo(t *testing.T) string {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		_, err := Run(dir, args...)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=1.0.0\n"), 0644))
+	_, err := Run(dir, "add", "-A")
+	assert.NoError(t, err)
+	_, err = Run(dir, "commit", "-q", "-m", "initial")
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestRequireClean(t *testing.T) {
+	assert.Error(t, RequireClean(t.TempDir()))
+
+	dir := initRepo(t)
+	assert.True(t, IsRepository(dir))
+	assert.NoError(t, RequireClean(dir))
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=2.0.0\n"), 0644))
+	err := RequireClean(dir)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), " M DefaultGame.ini")
+}
+
+func TestRun(t *testing.T) {
+	_, err := Run(t.TempDir(), "not-a-command")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "git not-a-command")
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := initRepo(t)
+	_, err := Run(dir, "branch", "base")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "Source"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Source", "Game.cpp"), []byte("// game\n"), 0644))
+	_, err = Run(dir, "add", "-A")
+	assert.NoError(t, err)
+	_, err = Run(dir, "commit", "-q", "-m", "source")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=2.0.0\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Notes.md"), []byte("notes\n"), 0644))
+
+	files, err := ChangedFiles(dir, "base")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"DefaultGame.ini", "Source/Game.cpp", "Notes.md"}, files)
+
+	files, err = ChangedFiles(filepath.Join(dir, "Source"), "base")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Game.cpp"}, files)
+
+	_, err = ChangedFiles(dir, "no-such-branch")
+	assert.Error(t, err)
+}
+
+func TestShow(t *testing.T) {
+	dir := initRepo(t)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=2.0.0\n"), 0644))
+
+	content, ok, err := Show(dir, "HEAD", "DefaultGame.ini")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "ProjectVersion=1.0.0\n", string(content))
+
+	_, ok, err = Show(dir, "HEAD", "Missing.ini")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Show(dir, "no-such-branch", "DefaultGame.ini")
+	assert.Error(t, err)
+}
+
+func TestMergeFile(t *testing.T) {
+	base := []byte("a\nb\nc\nd\ne\n")
+	merged, conflicts, err := MergeFile(base, []byte("A\nb\nc\nd\ne\n"), []byte("a\nb\nc\nd\nE\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, conflicts)
+	assert.Equal(t, "A\nb\nc\nd\nE\n", string(merged))
+
+	merged, conflicts, err = MergeFile(base, []byte("a\nB\nc\nd\ne\n"), []byte("a\nX\nc\nd\ne\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, conflicts)
+	assert.Contains(t, string(merged), "<<<<<<< ours\nB\n=======\nX\n>>>>>>> theirs\n")
+}
+
+func TestStagedFiles(t *testing.T) {
+	dir := initRepo(t)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=2.0.0\n"), 0644))
+	_, err := Run(dir, "add", "DefaultGame.ini")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion=3.0.0\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Untracked.ini"), []byte("\n"), 0644))
+
+	files, err := StagedFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DefaultGame.ini"}, files)
+
+	content, err := ShowStaged(dir, "DefaultGame.ini")
+	assert.NoError(t, err)
+	assert.Equal(t, "ProjectVersion=2.0.0\n", string(content))
+}
+
+func TestBranches(t *testing.T) {
+	dir := initRepo(t)
+	_, err := Run(dir, "branch", "release/1.0")
+	assert.NoError(t, err)
+	head, err := Run(dir, "rev-parse", "HEAD")
+	assert.NoError(t, err)
+	_, err = Run(dir, "update-ref", "refs/remotes/origin/main", head)
+	assert.NoError(t, err)
+	_, err = Run(dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	assert.NoError(t, err)
+
+	refs, err := Branches(dir, false)
+	assert.NoError(t, err)
+	assert.Len(t, refs, 2)
+	for _, ref := range refs {
+		assert.False(t, ref.Remote)
+		assert.Equal(t, head, ref.Commit)
+	}
+
+	refs, err = Branches(dir, true)
+	assert.NoError(t, err)
+	assert.Len(t, refs, 3)
+	assert.Equal(t, Ref{Name: "origin/main", Commit: head, Remote: true}, refs[2])
+}
+
+func TestMergeBase(t *testing.T) {
+	dir := initRepo(t)
+	head, err := Run(dir, "rev-parse", "HEAD")
+	assert.NoError(t, err)
+	_, err = Run(dir, "commit", "-q", "--allow-empty", "-m", "second")
+	assert.NoError(t, err)
+
+	base, err := MergeBase(dir, "HEAD", head)
+	assert.NoError(t, err)
+	assert.Equal(t, head, base)
+
+	_, err = MergeBase(dir, "HEAD", "no-such-branch")
+	assert.Error(t, err)
+}
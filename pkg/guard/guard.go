@@ -0,0 +1,127 @@
+package guard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/pkg/errors"
+)
+
+// IniKey is an ini value that stores must see increase with every release
+type IniKey struct {
+	Section string
+	Key     string
+}
+
+// MonotonicIniKeys are the ini values that may never go down
+var MonotonicIniKeys = []IniKey{
+	// Google Play rejects an upload whose version code isn't higher than the last one
+	{Section: "/Script/AndroidRuntimeSettings.AndroidRuntimeSettings", Key: "StoreVersion"},
+}
+
+// DowngradeError is returned when a write would not increase a value
+type DowngradeError struct {
+	// Name is the value being written, such as ProjectVersion
+	Name string
+	// Location is the file holding it
+	Location string
+	Current  string
+	Next     string
+	// Equal is true when the new value is the same as the current one rather than lower
+	Equal bool
+}
+
+func (e *DowngradeError) Error() string {
+	return fmt.Sprintf("refusing to %s; pass --allow-downgrade to write it anyway", e.describe())
+}
+
+func (e *DowngradeError) describe() string {
+	problem := "older than"
+	if e.Equal {
+		problem = "the same as"
+	}
+	return fmt.Sprintf("set %s in %s to %s, it is %s the current %s", e.Name, e.Location, e.Next, problem, e.Current)
+}
+
+// IsDowngrade returns true if the error blocked a downgrade
+func IsDowngrade(err error) bool {
+	_, ok := errors.Cause(err).(*DowngradeError)
+	return ok
+}
+
+// Guard refuses writes that don't increase a value
+type Guard struct {
+	// AllowDowngrade turns every refusal into a warning
+	AllowDowngrade bool
+}
+
+// Version checks next is newer than current in the scheme. A current value that doesn't follow the
+// scheme can't be compared, writing next migrates it to the scheme.
+func (g *Guard) Version(s scheme.Scheme, name string, location string, current string, next scheme.Version) error {
+	if current == "" {
+		return nil
+	}
+	currentVersion, err := s.Parse(current)
+	if err != nil {
+		log.Logger().Warnf("Migrating %s in %s from %s to the %s scheme without checking for a downgrade: %s", name, location, current, s.Name(), err)
+		return nil
+	}
+	return g.check(name, location, current, next.String(), s.Compare(next, currentVersion))
+}
+
+// Integer checks next is a larger whole number than current
+func (g *Guard) Integer(name string, location string, current string, next string) error {
+	n, err := strconv.ParseInt(strings.TrimSpace(next), 10, 64)
+	if err != nil {
+		return errors.Errorf("%s in %s must be a whole number but got %q", name, location, next)
+	}
+	if strings.TrimSpace(current) == "" {
+		return nil
+	}
+	c, err := strconv.ParseInt(strings.TrimSpace(current), 10, 64)
+	if err != nil {
+		return g.unchecked(name, location, errors.Errorf("the current %q is not a number", current))
+	}
+	compare := 0
+	switch {
+	case n < c:
+		compare = -1
+	case n > c:
+		compare = 1
+	}
+	return g.check(name, location, current, next, compare)
+}
+
+// IniKey checks the value if the key is one of MonotonicIniKeys
+func (g *Guard) IniKey(location string, section string, key string, current string, next string) error {
+	for _, monotonic := range MonotonicIniKeys {
+		if monotonic.Section == section && strings.EqualFold(monotonic.Key, key) {
+			return g.Integer(key, location, current, next)
+		}
+	}
+	return nil
+}
+
+// unchecked refuses a write whose current value can't be compared, unless downgrades are allowed
+func (g *Guard) unchecked(name string, location string, err error) error {
+	if g.AllowDowngrade {
+		log.Logger().Warnf("Not checking %s in %s for a downgrade: %s", name, location, err)
+		return nil
+	}
+	return errors.Wrapf(err, "can't check %s in %s for a downgrade, pass --allow-downgrade to write it anyway", name, location)
+}
+
+func (g *Guard) check(name string, location string, current string, next string, compare int) error {
+	if compare > 0 {
+		return nil
+	}
+	err := &DowngradeError{Name: name, Location: location, Current: current, Next: next, Equal: compare == 0}
+	if g.AllowDowngrade {
+		log.Logger().Warnf("Allowed to %s", err.describe())
+		return nil
+	}
+	return err
+}
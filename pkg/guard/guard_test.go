@@ -0,0 +1,70 @@
+package guard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	semver, err := scheme.Get("semver")
+	assert.NoError(t, err)
+	tests := []struct {
+		name           string
+		current        string
+		next           string
+		allowDowngrade bool
+		wantErr        string
+	}{
+		{"Upgrade", "2.3.0", "2.4.0", false, ""},
+		{"Release of a prerelease", "2.4.0-rc.1", "2.4.0", false, ""},
+		{"Downgrade", "2.3.0", "0.1.0", false, "refusing to set ProjectVersion in DefaultGame.ini to 0.1.0, it is older than the current 2.3.0; pass --allow-downgrade to write it anyway"},
+		{"Same", "2.3.0", "2.3.0", false, "refusing to set ProjectVersion in DefaultGame.ini to 2.3.0, it is the same as the current 2.3.0; pass --allow-downgrade to write it anyway"},
+		{"Allowed downgrade", "2.3.0", "0.1.0", true, ""},
+		{"No current version", "", "0.1.0", false, ""},
+		{"Migration from another scheme", "1.0.0.0", "0.1.0", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := semver.Parse(tt.next)
+			assert.NoError(t, err)
+			g := &Guard{AllowDowngrade: tt.allowDowngrade}
+			err = g.Version(semver, "ProjectVersion", "DefaultGame.ini", tt.current, next)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.True(t, strings.HasPrefix(err.Error(), tt.wantErr), err.Error())
+			}
+			assert.Equal(t, strings.HasPrefix(tt.wantErr, "refusing"), IsDowngrade(err))
+		})
+	}
+}
+
+func TestInteger(t *testing.T) {
+	g := &Guard{}
+	assert.NoError(t, g.Integer("Version", "A.uplugin", "4", "5"))
+	assert.NoError(t, g.Integer("Version", "A.uplugin", "", "1"))
+	assert.True(t, IsDowngrade(g.Integer("Version", "A.uplugin", "10", "9")))
+	assert.True(t, IsDowngrade(g.Integer("Version", "A.uplugin", "10", "10")))
+
+	err := g.Integer("Version", "A.uplugin", "4", "five")
+	assert.Error(t, err)
+	assert.False(t, IsDowngrade(err))
+
+	err = g.Integer("Version", "A.uplugin", "four", "5")
+	assert.Error(t, err)
+	assert.False(t, IsDowngrade(err))
+	assert.NoError(t, (&Guard{AllowDowngrade: true}).Integer("Version", "A.uplugin", "four", "5"))
+}
+
+func TestIniKey(t *testing.T) {
+	g := &Guard{}
+	const android = "/Script/AndroidRuntimeSettings.AndroidRuntimeSettings"
+	assert.NoError(t, g.IniKey("DefaultEngine.ini", android, "StoreVersion", "10", "11"))
+	assert.True(t, IsDowngrade(g.IniKey("DefaultEngine.ini", android, "StoreVersion", "10", "9")))
+	assert.NoError(t, g.IniKey("DefaultEngine.ini", android, "VersionDisplayName", "2.0", "1.0"))
+}
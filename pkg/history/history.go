@@ -0,0 +1,120 @@
+package history
+
+import (
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/pkg/errors"
+)
+
+const (
+	recordSeparator = "\x1e"
+	fieldSeparator  = "\x1f"
+)
+
+// Entry is a commit that changed the value
+type Entry struct {
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	ShortCommit string `json:"-"`
+	Author      string `json:"author"`
+	// Date is the author date in ISO 8601
+	Date    string `json:"date"`
+	Subject string `json:"subject"`
+	// Previous is the value before the commit, empty when the commit added it
+	Previous string `json:"previous,omitempty"`
+	// Downgrade is true when the scheme doesn't order the value after the previous one
+	Downgrade bool `json:"downgrade,omitempty"`
+}
+
+// Changes walks the commits of the file at path, relative to dir, following renames. read returns the
+// value at each commit and the commits that changed it are returned, newest first. since limits the walk
+// the way git log --since does, the value before the window is still what the first commit in it is
+// compared with. Values are ordered by the scheme to flag downgrades.
+//
+// Only the first parent of merges is followed, so each commit is compared with the one before it on the
+// branch and a version set while resolving a merge is credited to the merge.
+func Changes(dir string, path string, since string, s scheme.Scheme, read func(content []byte) (string, error)) ([]Entry, error) {
+	args := []string{"log", "--follow", "--first-parent", "-m", "--name-only", "--format=" + recordSeparator + strings.Join([]string{"%H", "%h", "%an", "%aI", "%s"}, fieldSeparator)}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	out, err := gitutil.Run(dir, append(args, "--", path)...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Entry
+	var paths []string
+	for _, record := range strings.Split(out, recordSeparator) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		lines := strings.SplitN(strings.TrimSpace(record), "\n", 2)
+		fields := strings.Split(lines[0], fieldSeparator)
+		if len(fields) != 5 || len(lines) < 2 {
+			return nil, errors.Errorf("unexpected git log output %q", record)
+		}
+		if n := len(commits); n > 0 && commits[n-1].Commit == fields[0] {
+			// -m lists a merge once for each parent it is compared with
+			continue
+		}
+		commits = append(commits, Entry{Commit: fields[0], ShortCommit: fields[1], Author: fields[2], Date: fields[3], Subject: fields[4]})
+		paths = append(paths, strings.TrimSpace(lines[1]))
+	}
+
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	// walk from the oldest commit so each version is credited to the commit that introduced it
+	var answer []Entry
+	previous := ""
+	oldest := len(commits) - 1
+	if content, err := gitutil.RunBytes(dir, nil, "show", commits[oldest].Commit+"^:"+paths[oldest]); err == nil {
+		// the oldest commit has a parent holding the file, so the walk started inside the history
+		if previous, err = read(content); err != nil {
+			return nil, errors.Wrapf(err, "reading %s before %s", paths[oldest], commits[oldest].ShortCommit)
+		}
+	}
+	for i := oldest; i >= 0; i-- {
+		content, err := gitutil.RunBytes(dir, nil, "show", commits[i].Commit+":"+paths[i])
+		if err != nil {
+			// the file is deleted in this commit
+			previous = ""
+			continue
+		}
+		value, err := read(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s at %s", paths[i], commits[i].ShortCommit)
+		}
+		if value == "" || value == previous {
+			previous = value
+			continue
+		}
+		entry := commits[i]
+		entry.Version = value
+		entry.Previous = previous
+		entry.Downgrade = isDowngrade(s, previous, value)
+		previous = value
+		answer = append([]Entry{entry}, answer...)
+	}
+	return answer, nil
+}
+
+// isDowngrade returns true if next is older than or the same as previous, values the scheme can't read
+// aren't ordered
+func isDowngrade(s scheme.Scheme, previous string, next string) bool {
+	if previous == "" {
+		return false
+	}
+	x, err := s.Parse(previous)
+	if err != nil {
+		return false
+	}
+	y, err := s.Parse(next)
+	if err != nil {
+		return false
+	}
+	return s.Compare(y, x) <= 0
+}
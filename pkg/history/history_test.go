@@ -0,0 +1,118 @@
+package history
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanges(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) {
+		_, err := gitutil.Run(dir, args...)
+		assert.NoError(t, err)
+	}
+	day := 0
+	commit := func(path string, content string, message string) {
+		// a day apart so --since can start the walk between two commits
+		day++
+		date := fmt.Sprintf("2024-01-%02dT12:00:00Z", day)
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+		run("add", "-A")
+		run("commit", "-q", "-m", message)
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	run("config", "commit.gpgsign", "false")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.0.0\n", "first")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.0.0\nCompanyName=Epic\n", "company")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.1.0\nCompanyName=Epic\n", "bump")
+	commit("Source/Game.cpp", "// game\n", "code")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.2.0\nCompanyName=Epic\n", "bump again")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.2.0\nCompanyName=Epic Games\n", "rename company")
+	commit("Config/DefaultGame.ini", "ProjectVersion=1.1.5\nCompanyName=Epic Games\n", "revert")
+	semver, err := scheme.Get("semver")
+	assert.NoError(t, err)
+
+	read := func(content []byte) (string, error) {
+		return strings.TrimSpace(strings.TrimPrefix(strings.Split(string(content), "\n")[0], "ProjectVersion=")), nil
+	}
+	entries, err := Changes(dir, "Config/DefaultGame.ini", "", semver, read)
+	assert.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, fmt.Sprintf("%s %s %s %t", e.Version, e.Subject, e.Author, e.Downgrade))
+		assert.Len(t, e.Commit, 40)
+		assert.NotEmpty(t, e.Date)
+	}
+	assert.Equal(t, []string{"1.1.5 revert Test true", "1.2.0 bump again Test false", "1.1.0 bump Test false", "1.0.0 first Test false"}, got)
+	assert.Equal(t, "1.2.0", entries[0].Previous)
+	assert.Equal(t, "", entries[3].Previous)
+
+	entries, err = Changes(dir, "Config/DefaultGame.ini", "2000-01-01", semver, read)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	// the window starts at "company", which didn't change the version set before it
+	entries, err = Changes(dir, "Config/DefaultGame.ini", "2024-01-02T00:00:00Z", semver, read)
+	assert.NoError(t, err)
+	got = nil
+	for _, e := range entries {
+		got = append(got, e.Previous+" -> "+e.Version)
+	}
+	assert.Equal(t, []string{"1.2.0 -> 1.1.5", "1.1.0 -> 1.2.0", "1.0.0 -> 1.1.0"}, got)
+}
+
+func TestChanges_Merge(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) error {
+		_, err := gitutil.Run(dir, args...)
+		return err
+	}
+	day := 0
+	commit := func(version string, message string) {
+		day++
+		date := fmt.Sprintf("2024-01-%02dT12:00:00Z", day)
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte("ProjectVersion="+version+"\n"), 0644))
+		assert.NoError(t, run("add", "-A"))
+		assert.NoError(t, run("commit", "-q", "-m", message))
+	}
+	assert.NoError(t, run("init", "-q"))
+	assert.NoError(t, run("config", "user.email", "test@example.com"))
+	assert.NoError(t, run("config", "user.name", "Test"))
+	assert.NoError(t, run("config", "commit.gpgsign", "false"))
+	commit("1.0.0", "first")
+	assert.NoError(t, run("checkout", "-q", "-b", "feature"))
+	commit("1.2.0", "feature")
+	assert.NoError(t, run("checkout", "-q", "-"))
+	commit("1.1.0", "hotfix")
+	// the versions conflict and the merge settles on a third one
+	assert.Error(t, run("merge", "-q", "feature"))
+	commit("1.3.0", "merge feature")
+
+	semver, err := scheme.Get("semver")
+	assert.NoError(t, err)
+	read := func(content []byte) (string, error) {
+		return strings.TrimSpace(strings.TrimPrefix(string(content), "ProjectVersion=")), nil
+	}
+	entries, err := Changes(dir, "DefaultGame.ini", "", semver, read)
+	assert.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, fmt.Sprintf("%s -> %s %s %t", e.Previous, e.Version, e.Subject, e.Downgrade))
+	}
+	assert.Equal(t, []string{"1.1.0 -> 1.3.0 merge feature false", "1.0.0 -> 1.1.0 hotfix false", " -> 1.0.0 first false"}, got)
+}
@@ -0,0 +1,112 @@
+package inimerge
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"gopkg.in/ini.v1"
+)
+
+// side is one of the two versions of a file being merged
+type side int
+
+const (
+	none side = iota
+	ours
+	theirs
+)
+
+// Resolver merges unreal config files, settling the lines release branches always conflict on before
+// merging the rest line by line
+type Resolver struct {
+	// Scheme orders the versions, nil infers it from our version as scheme.Infer does
+	Scheme scheme.Scheme
+	// Version is the key holding the version, the higher version wins
+	Version guard.IniKey
+	// Followers are keys derived from the version, such as a title rendered from a template. They take
+	// the value of the side whose version won.
+	Followers []guard.IniKey
+	// Monotonic are whole numbers that only increase, the larger one wins
+	Monotonic []guard.IniKey
+}
+
+// Merge merges the changes from base to theirs into ours and returns the result and the number of
+// conflicts left in it
+func (r *Resolver) Merge(base []byte, oursContent []byte, theirsContent []byte) ([]byte, int, error) {
+	files := map[side][]byte{ours: oursContent, theirs: theirsContent}
+	values := map[side]*ini.File{}
+	for _, s := range []side{ours, theirs} {
+		cfg, err := ueini.Load(files[s])
+		if err != nil {
+			return nil, 0, err
+		}
+		values[s] = cfg
+	}
+	get := func(s side, k guard.IniKey) string {
+		return values[s].Section(k.Section).Key(k.Key).String()
+	}
+	// the resolved value is written to the base as well, so the line isn't a change on either side and
+	// can't make the changes around it overlap
+	copyKey := func(from side, k guard.IniKey) {
+		value := get(from, k)
+		files[ours], _ = ueini.ReplaceValue(files[ours], k.Section, k.Key, value)
+		files[theirs], _ = ueini.ReplaceValue(files[theirs], k.Section, k.Key, value)
+		base, _ = ueini.ReplaceValue(base, k.Section, k.Key, value)
+	}
+
+	winner := r.winner(get(ours, r.Version), get(theirs, r.Version))
+	if winner != none {
+		log.Logger().Infof("Resolved %s to %s", r.Version.Key, get(winner, r.Version))
+		copyKey(winner, r.Version)
+		for _, k := range r.Followers {
+			if get(winner, k) != "" {
+				copyKey(winner, k)
+			}
+		}
+	}
+	for _, k := range r.Monotonic {
+		a, errA := strconv.ParseInt(strings.TrimSpace(get(ours, k)), 10, 64)
+		b, errB := strconv.ParseInt(strings.TrimSpace(get(theirs, k)), 10, 64)
+		if errA != nil || errB != nil || a == b {
+			continue
+		}
+		larger := ours
+		if b > a {
+			larger = theirs
+		}
+		log.Logger().Infof("Resolved %s to %s", k.Key, get(larger, k))
+		copyKey(larger, k)
+	}
+	return gitutil.MergeFile(base, files[ours], files[theirs])
+}
+
+// winner returns the side with the higher version, none when they agree or can't be ordered. A side
+// that kept the base version still wins over one that lowered it.
+func (r *Resolver) winner(a string, b string) side {
+	if a == b || a == "" || b == "" {
+		return none
+	}
+	s := r.Scheme
+	if s == nil {
+		s = scheme.Infer(a)
+	}
+	x, err := s.Parse(a)
+	if err != nil {
+		log.Logger().Warnf("Leaving %s to be merged by hand: %s", r.Version.Key, err)
+		return none
+	}
+	y, err := s.Parse(b)
+	if err != nil {
+		log.Logger().Warnf("Leaving %s to be merged by hand: %s", r.Version.Key, err)
+		return none
+	}
+	if s.Compare(x, y) < 0 {
+		return theirs
+	}
+	return ours
+}
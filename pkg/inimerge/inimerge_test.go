@@ -0,0 +1,102 @@
+package inimerge
+
+import (
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/stretchr/testify/assert"
+)
+
+const base = `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectName=Shooter
+ProjectVersion=1.2.0
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "Title", "Shooter 1.2.0")
+CompanyName=Epic
+
+[/Script/AndroidRuntimeSettings.AndroidRuntimeSettings]
+StoreVersion=10
+`
+
+func resolver() *Resolver {
+	return &Resolver{
+		Scheme:    scheme.SemVer{},
+		Version:   guard.IniKey{Section: ueini.GeneralProjectSettings, Key: "ProjectVersion"},
+		Followers: []guard.IniKey{{Section: ueini.GeneralProjectSettings, Key: "ProjectDisplayedTitle"}},
+		Monotonic: guard.MonotonicIniKeys,
+	}
+}
+
+func TestResolver_Merge(t *testing.T) {
+	ours := `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectName=Shooter
+ProjectVersion=1.3.0
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "Title", "Shooter 1.3.0")
+CompanyName=Epic Games
+
+[/Script/AndroidRuntimeSettings.AndroidRuntimeSettings]
+StoreVersion=12
+`
+	theirs := `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectName=Shooter
+ProjectVersion=1.2.1
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "Title", "Shooter 1.2.1")
+CompanyName=Epic
+
+[/Script/AndroidRuntimeSettings.AndroidRuntimeSettings]
+StoreVersion=11
+bPackageDataInsideApk=True
+`
+	merged, conflicts, err := resolver().Merge([]byte(base), []byte(ours), []byte(theirs))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, conflicts)
+	assert.Equal(t, `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectName=Shooter
+ProjectVersion=1.3.0
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "Title", "Shooter 1.3.0")
+CompanyName=Epic Games
+
+[/Script/AndroidRuntimeSettings.AndroidRuntimeSettings]
+StoreVersion=12
+bPackageDataInsideApk=True
+`, string(merged))
+
+	merged, conflicts, err = resolver().Merge([]byte(base), []byte(theirs), []byte(ours))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, conflicts)
+	assert.Contains(t, string(merged), "ProjectVersion=1.3.0\n")
+	assert.Contains(t, string(merged), "StoreVersion=12\n")
+}
+
+func TestResolver_MergeConflict(t *testing.T) {
+	ours := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Shooter\nProjectVersion=1.3.0\n"
+	theirs := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Arena\nProjectVersion=1.2.1\n"
+	base := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Game\nProjectVersion=1.2.0\n"
+	merged, conflicts, err := resolver().Merge([]byte(base), []byte(ours), []byte(theirs))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, conflicts)
+	assert.Contains(t, string(merged), "<<<<<<< ours\nProjectName=Shooter\n=======\nProjectName=Arena\n>>>>>>> theirs\nProjectVersion=1.3.0\n")
+}
+
+func TestResolver_Unparseable(t *testing.T) {
+	ours := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=latest\n"
+	theirs := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.1\n"
+	base := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.0\n"
+	_, conflicts, err := resolver().Merge([]byte(base), []byte(ours), []byte(theirs))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, conflicts)
+}
+
+func TestResolver_OneSidedDowngrade(t *testing.T) {
+	// theirs rolled the version back while ours kept it, the higher version still wins
+	ours := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.0\nCompanyName=Epic Games\n"
+	theirs := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.1.0\nCompanyName=Epic\n"
+	base := "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.0\nCompanyName=Epic\n"
+	for _, sides := range [][2]string{{ours, theirs}, {theirs, ours}} {
+		merged, conflicts, err := resolver().Merge([]byte(base), []byte(sides[0]), []byte(sides[1]))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, conflicts)
+		assert.Equal(t, "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.0\nCompanyName=Epic Games\n", string(merged))
+	}
+}
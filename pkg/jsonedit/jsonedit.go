@@ -0,0 +1,487 @@
+package jsonedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Kind is the type of a JSON value
+type Kind int
+
+const (
+	KindObject Kind = iota
+	KindArray
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+)
+
+// Node is a parsed JSON value which remembers where it lives in the original document so it can be
+// replaced without reformatting anything around it
+type Node struct {
+	Kind     Kind
+	Start    int
+	End      int
+	Members  []Member
+	Elements []*Node
+}
+
+// Member is a key inside a JSON object
+type Member struct {
+	Key      string
+	KeyStart int
+	Value    *Node
+}
+
+// Parse parses a JSON document keeping the byte offsets of every value
+func Parse(data []byte) (*Node, error) {
+	p := &parser{data: data}
+	p.skipSpace()
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.data) {
+		return nil, p.errorf("unexpected trailing data")
+	}
+	return node, nil
+}
+
+// Raw returns the original text of the node
+func (n *Node) Raw(data []byte) []byte {
+	return data[n.Start:n.End]
+}
+
+// Member returns the value of the given key if the node is an object
+func (n *Node) Member(key string) *Node {
+	if n.Kind != KindObject {
+		return nil
+	}
+	for _, m := range n.Members {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return nil
+}
+
+// Lookup resolves a path such as `$.version`, `build.version` or `Modules[0].Name`
+func (n *Node) Lookup(path string) (*Node, error) {
+	segments, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	current := n
+	for _, segment := range segments {
+		if index, ok := segment.index(); ok {
+			if current.Kind != KindArray {
+				return nil, errors.Errorf("%s: [%d] used on a value that is not an array", path, index)
+			}
+			if index < 0 || index >= len(current.Elements) {
+				return nil, errors.Errorf("%s: index %d out of range", path, index)
+			}
+			current = current.Elements[index]
+			continue
+		}
+		if current.Kind != KindObject {
+			return nil, errors.Errorf("%s: %s used on a value that is not an object", path, segment)
+		}
+		next := current.Member(string(segment))
+		if next == nil {
+			return nil, &NotFoundError{Path: path, Key: string(segment)}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// NotFoundError is returned when a path does not exist in a document
+type NotFoundError struct {
+	Path string
+	Key  string
+}
+
+func (e *NotFoundError) Error() string {
+	return "key " + e.Key + " not found for path " + e.Path
+}
+
+// IsNotFound returns true if the error is a NotFoundError
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*NotFoundError)
+	return ok
+}
+
+type segment string
+
+func (s segment) index() (int, bool) {
+	if !strings.HasPrefix(string(s), "[") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(string(s), "["), "]"))
+	return i, err == nil
+}
+
+// splitPath splits a path expression into keys and `[n]` array indexes
+func splitPath(path string) ([]segment, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	var segments []segment
+	if path == "" {
+		return segments, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, errors.Errorf("invalid path %q: empty key", path)
+		}
+		for part != "" {
+			open := strings.Index(part, "[")
+			if open < 0 {
+				segments = append(segments, segment(part))
+				break
+			}
+			if open > 0 {
+				segments = append(segments, segment(part[:open]))
+			}
+			end := strings.Index(part, "]")
+			if end < open {
+				return nil, errors.Errorf("invalid path %q: unterminated index", path)
+			}
+			idx := segment(part[open : end+1])
+			if _, ok := idx.index(); !ok {
+				return nil, errors.Errorf("invalid path %q: bad index %s", path, idx)
+			}
+			segments = append(segments, idx)
+			part = part[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+// Get returns the raw JSON text at the given path
+func Get(data []byte, path string) ([]byte, error) {
+	root, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	node, err := root.Lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	return node.Raw(data), nil
+}
+
+// GetString returns the string value at the given path, numbers and bools are returned as written
+func GetString(data []byte, path string) (string, error) {
+	raw, err := Get(data, path)
+	if err != nil {
+		return "", err
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	return string(raw), nil
+}
+
+// Set replaces the value at the given path with the JSON encoding of value. Everything else in the
+// document, including whitespace and key order, is left untouched. If the last key of the path is
+// missing from its object it is appended to that object.
+func Set(data []byte, path string, value interface{}) ([]byte, error) {
+	encoded, err := marshal(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "encoding value for %s", path)
+	}
+	return SetRaw(data, path, encoded)
+}
+
+// marshal encodes a value without the HTML escaping json.Marshal applies, so descriptions containing
+// `<` or `&` are written the way a person would type them
+func marshal(value interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// SetRaw is like Set but takes already encoded JSON
+func SetRaw(data []byte, path string, raw []byte) ([]byte, error) {
+	root, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	node, err := root.Lookup(path)
+	if err == nil {
+		return splice(data, node.Start, node.End, raw), nil
+	}
+	if !IsNotFound(err) {
+		return nil, err
+	}
+
+	segments, _ := splitPath(path)
+	last := segments[len(segments)-1]
+	if _, isIndex := last.index(); isIndex {
+		return nil, err
+	}
+	parentPath := joinPath(segments[:len(segments)-1])
+	parent, perr := root.Lookup(parentPath)
+	if perr != nil {
+		return nil, err
+	}
+	if parent.Kind != KindObject {
+		return nil, errors.Errorf("%s: parent is not an object", path)
+	}
+	return insertMember(data, parent, string(last), raw), nil
+}
+
+func joinPath(segments []segment) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if _, ok := s.index(); !ok && b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(string(s))
+	}
+	return b.String()
+}
+
+func splice(data []byte, start, end int, replacement []byte) []byte {
+	answer := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	answer = append(answer, data[:start]...)
+	answer = append(answer, replacement...)
+	answer = append(answer, data[end:]...)
+	return answer
+}
+
+// insertMember appends a key to an object, copying the indentation and separator style of the
+// existing members
+func insertMember(data []byte, object *Node, key string, raw []byte) []byte {
+	encodedKey, _ := marshal(key)
+	if len(object.Members) == 0 {
+		member := append(append(encodedKey, ": "...), raw...)
+		return splice(data, object.Start+1, object.End-1, member)
+	}
+	last := object.Members[len(object.Members)-1]
+	indent := lineIndent(data, last.KeyStart)
+	separator := ": "
+	if colon := strings.LastIndexByte(string(data[last.KeyStart:last.Value.Start]), ':'); colon >= 0 {
+		separator = string(data[last.KeyStart+colon : last.Value.Start])
+	}
+	var b strings.Builder
+	b.WriteByte(',')
+	if indent != "" || precededByNewline(data, last.KeyStart) {
+		b.WriteString(newlineStyle(data))
+		b.WriteString(indent)
+	} else {
+		b.WriteByte(' ')
+	}
+	b.Write(encodedKey)
+	b.WriteString(separator)
+	b.Write(raw)
+	return splice(data, last.Value.End, last.Value.End, []byte(b.String()))
+}
+
+func lineIndent(data []byte, pos int) string {
+	start := pos
+	for start > 0 && (data[start-1] == ' ' || data[start-1] == '\t') {
+		start--
+	}
+	if start > 0 && data[start-1] != '\n' {
+		return ""
+	}
+	return string(data[start:pos])
+}
+
+func precededByNewline(data []byte, pos int) bool {
+	for pos > 0 {
+		pos--
+		switch data[pos] {
+		case ' ', '\t', '\r':
+			continue
+		case '\n':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func newlineStyle(data []byte) string {
+	if strings.Contains(string(data), "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("invalid JSON at offset %d: %s", p.pos, errors.Errorf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) value() (*Node, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"':
+		start := p.pos
+		if _, err := p.str(); err != nil {
+			return nil, err
+		}
+		return &Node{Kind: KindString, Start: start, End: p.pos}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	default:
+		return p.literal()
+	}
+}
+
+func (p *parser) object() (*Node, error) {
+	node := &Node{Kind: KindObject, Start: p.pos}
+	p.pos++
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == '}' {
+		p.pos++
+		node.End = p.pos
+		return node, nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+			return nil, p.errorf("expected object key")
+		}
+		keyStart := p.pos
+		key, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.errorf("expected ':' after key %q", key)
+		}
+		p.pos++
+		p.skipSpace()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		node.Members = append(node.Members, Member{Key: key, KeyStart: keyStart, Value: value})
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated object")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			node.End = p.pos
+			return node, nil
+		default:
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *parser) array() (*Node, error) {
+	node := &Node{Kind: KindArray, Start: p.pos}
+	p.pos++
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == ']' {
+		p.pos++
+		node.End = p.pos
+		return node, nil
+	}
+	for {
+		p.skipSpace()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		node.Elements = append(node.Elements, value)
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unterminated array")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			node.End = p.pos
+			return node, nil
+		default:
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *parser) str() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal(p.data[start:p.pos], &s); err != nil {
+				return "", p.errorf("bad string: %s", err)
+			}
+			return s, nil
+		default:
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) number() (*Node, error) {
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte("+-0123456789.eE", p.data[p.pos]) >= 0 {
+		p.pos++
+	}
+	if _, err := strconv.ParseFloat(string(p.data[start:p.pos]), 64); err != nil {
+		return nil, p.errorf("bad number %q", p.data[start:p.pos])
+	}
+	return &Node{Kind: KindNumber, Start: start, End: p.pos}, nil
+}
+
+func (p *parser) literal() (*Node, error) {
+	for word, kind := range map[string]Kind{"true": KindBool, "false": KindBool, "null": KindNull} {
+		if strings.HasPrefix(string(p.data[p.pos:]), word) {
+			start := p.pos
+			p.pos += len(word)
+			return &Node{Kind: kind, Start: start, End: p.pos}, nil
+		}
+	}
+	return nil, p.errorf("unexpected character %q", p.data[p.pos])
+}
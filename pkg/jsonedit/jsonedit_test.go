@@ -0,0 +1,120 @@
+package jsonedit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const uproject = `{
+	"FileVersion": 3,
+	"EngineAssociation": "5.2",
+	"Category": "",
+	"Modules": [
+		{
+			"Name": "MyGame",
+			"Type": "Runtime"
+		}
+	]
+}
+`
+
+func TestSet(t *testing.T) {
+	type args struct {
+		data  string
+		path  string
+		value interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"Replace string", args{uproject, "EngineAssociation", "5.3"}, `{
+	"FileVersion": 3,
+	"EngineAssociation": "5.3",
+	"Category": "",
+	"Modules": [
+		{
+			"Name": "MyGame",
+			"Type": "Runtime"
+		}
+	]
+}
+`, false},
+		{"Replace number", args{uproject, "$.FileVersion", 4}, `{
+	"FileVersion": 4,
+	"EngineAssociation": "5.2",
+	"Category": "",
+	"Modules": [
+		{
+			"Name": "MyGame",
+			"Type": "Runtime"
+		}
+	]
+}
+`, false},
+		{"Nested", args{uproject, "Modules[0].Name", "Other"}, `{
+	"FileVersion": 3,
+	"EngineAssociation": "5.2",
+	"Category": "",
+	"Modules": [
+		{
+			"Name": "Other",
+			"Type": "Runtime"
+		}
+	]
+}
+`, false},
+		{"Insert", args{`{
+    "a": 1,
+    "b": 2
+}`, "c", "x&y"}, `{
+    "a": 1,
+    "b": 2,
+    "c": "x&y"
+}`, false},
+		{"Insert compact", args{`{"a":1}`, "b", true}, `{"a":1, "b":true}`, false},
+		{"Insert empty", args{`{}`, "b", 1}, `{"b": 1}`, false},
+		{"Missing parent", args{`{}`, "a.b", 1}, "", true},
+		{"Out of range", args{uproject, "Modules[3].Name", 1}, "", true},
+		{"Invalid JSON", args{`{"a":`, "a", 1}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Set([]byte(tt.args.data), tt.args.path, tt.args.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGetString(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"String", "EngineAssociation", "5.2", false},
+		{"Number", "FileVersion", "3", false},
+		{"Nested", "$.Modules[0].Type", "Runtime", false},
+		{"Missing", "Nope", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetString([]byte(uproject), tt.path)
+			if tt.wantErr {
+				assert.True(t, IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
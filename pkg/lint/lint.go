@@ -0,0 +1,128 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+// Extensions are the files Checker knows how to check, besides engine Build.version files
+var Extensions = []string{".ini", ".uproject", ".uplugin"}
+
+// buildVersion is the name of the file holding an engine's version
+const buildVersion = "build.version"
+
+// conflictMarkers start the lines git writes around a conflict it couldn't merge
+var conflictMarkers = []string{"<<<<<<< ", "<<<<<<<\n", "||||||| ", "=======\n", ">>>>>>> ", ">>>>>>>\n"}
+
+// Checker validates config files and descriptors before they are committed
+type Checker struct {
+	// Scheme the version must follow, nil infers it from the version as scheme.Infer does
+	Scheme scheme.Scheme
+	// Version is the ini key that must hold a version of the scheme wherever it is set
+	Version guard.IniKey
+}
+
+// Supports returns true if the file is one of Extensions or a Build.version
+func Supports(path string) bool {
+	if strings.ToLower(filepath.Base(path)) == buildVersion {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns every problem of the file, nil if there are none
+func (c *Checker) Check(path string, content []byte) []error {
+	if lines := ConflictMarkers(content); len(lines) > 0 {
+		return []error{errors.Errorf("%s has merge conflict markers on line %s", path, joinInts(lines))}
+	}
+	if strings.ToLower(filepath.Base(path)) == buildVersion {
+		return checkBuildVersion(path, content)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ini":
+		return c.checkIni(path, content)
+	case ".uproject":
+		if _, err := descriptor.ParseProject(path, content); err != nil {
+			return []error{err}
+		}
+	case ".uplugin":
+		if _, err := descriptor.ParsePlugin(path, content); err != nil {
+			return []error{err}
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkIni(path string, content []byte) []error {
+	cfg, err := ueini.Load(content)
+	if err != nil {
+		return []error{errors.Wrapf(err, "parsing %s", path)}
+	}
+	section, err := cfg.GetSection(c.Version.Section)
+	if err != nil || !section.HasKey(c.Version.Key) {
+		return nil
+	}
+	value := section.Key(c.Version.Key).String()
+	s := c.Scheme
+	if s == nil {
+		s = scheme.Infer(value)
+	}
+	if err := scheme.Validate(s, value); err != nil {
+		return []error{errors.Wrapf(err, "%s in %s", c.Version.Key, path)}
+	}
+	return nil
+}
+
+// checkBuildVersion flags engine versions the engine would reject, such as a CompatibleChangelist after
+// the Changelist
+func checkBuildVersion(path string, content []byte) []error {
+	version, err := engines.ParseBuildVersion(content)
+	if err == nil {
+		err = version.Check()
+	}
+	if err != nil {
+		return []error{errors.Wrapf(err, "%s", path)}
+	}
+	return nil
+}
+
+// ConflictMarkers returns the line numbers, counting from 1, of the conflict markers in content
+func ConflictMarkers(content []byte) []int {
+	var answer []int
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r") + "\n"
+		for _, marker := range conflictMarkers {
+			if strings.HasPrefix(line, marker) {
+				answer = append(answer, n)
+				break
+			}
+		}
+	}
+	return answer
+}
+
+func joinInts(values []int) string {
+	var parts []string
+	for _, v := range values {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return strings.Join(parts, ", ")
+}
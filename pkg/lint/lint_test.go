@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/scheme"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	c := &Checker{
+		Scheme:  scheme.SemVer{},
+		Version: guard.IniKey{Section: ueini.GeneralProjectSettings, Key: "ProjectVersion"},
+	}
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{"Valid ini", "Config/DefaultGame.ini", "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.3\n", ""},
+		{"Ini without a version", "Config/DefaultEngine.ini", "[/Script/Engine.Engine]\nbSmoothFrameRate=True\n", ""},
+		{"Invalid version", "Config/DefaultGame.ini", "[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2\n", `ProjectVersion in Config/DefaultGame.ini: "1.2" is not a valid semver version`},
+		{"Conflict", "Config/DefaultGame.ini", "[A]\r\n<<<<<<< HEAD\r\nKey=1\r\n=======\r\nKey=2\r\n>>>>>>> main\r\n", "Config/DefaultGame.ini has merge conflict markers on line 2, 4, 6"},
+		{"Valid project", "Game.uproject", `{"FileVersion": 3, "EngineAssociation": "5.3"}`, ""},
+		{"Broken project", "Game.uproject", `{"FileVersion": 3,}`, "parsing project Game.uproject"},
+		{"Plugin version must be a number", "Plugins/P/P.uplugin", `{"Version": "2"}`, "parsing plugin Plugins/P/P.uplugin"},
+		{"Valid engine", "Engine/Build/Build.version", `{"MajorVersion": 5, "Changelist": 200, "CompatibleChangelist": 100}`, ""},
+		{"Incompatible engine", "Engine/Build/Build.version", `{"MajorVersion": 5, "Changelist": 100, "CompatibleChangelist": 200}`, "Engine/Build/Build.version: CompatibleChangelist 200 is greater than Changelist 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := c.Check(tt.path, []byte(tt.content))
+			if tt.want == "" {
+				assert.Empty(t, problems)
+				return
+			}
+			if assert.Len(t, problems, 1) {
+				assert.Contains(t, problems[0].Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_CheckInferredScheme(t *testing.T) {
+	c := &Checker{Version: guard.IniKey{Section: ueini.GeneralProjectSettings, Key: "ProjectVersion"}}
+	assert.Empty(t, c.Check("Config/DefaultGame.ini", []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.0.0.0\n")))
+	assert.Empty(t, c.Check("Config/DefaultGame.ini", []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2.3-rc.1\n")))
+	assert.Len(t, c.Check("Config/DefaultGame.ini", []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectVersion=1.2\n")), 1)
+}
+
+func TestSupports(t *testing.T) {
+	assert.True(t, Supports("Config/DefaultGame.ini"))
+	assert.True(t, Supports("Game.UPROJECT"))
+	assert.True(t, Supports("Engine/Build/Build.version"))
+	assert.False(t, Supports("Source/Game.cpp"))
+}
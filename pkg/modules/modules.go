@@ -0,0 +1,113 @@
+package modules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/jsonedit"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Extension is the file extension of the module manifests unreal writes next to compiled binaries
+	Extension = ".modules"
+
+	// BuildIDKey is the manifest key holding the id of the build the binaries came from. The editor
+	// refuses to load modules whose BuildId differs from the engine's.
+	BuildIDKey = "BuildId"
+
+	// BinariesDir is the folder of a project, plugin or engine holding one folder of binaries per platform
+	BinariesDir = "Binaries"
+)
+
+// Manifest is the content of a .modules file such as Binaries/Win64/UnrealEditor.modules
+type Manifest struct {
+	Path    string            `json:"-"`
+	Content []byte            `json:"-"`
+	BuildID string            `json:"BuildId"`
+	Modules map[string]string `json:"Modules"`
+}
+
+// Load reads and parses a .modules file
+func Load(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return Parse(path, data)
+}
+
+// Parse parses the content of a .modules file
+func Parse(path string, data []byte) (*Manifest, error) {
+	answer := &Manifest{Path: path, Content: data}
+	if err := json.Unmarshal(data, answer); err != nil {
+		return nil, errors.Wrapf(err, "parsing module manifest %s", path)
+	}
+	return answer, nil
+}
+
+// Key identifies the manifest across the binaries of the engine, a project and its plugins: the
+// platform folder and the file name, such as Win64/UnrealEditor.modules
+func (m *Manifest) Key() string {
+	return Key(m.Path)
+}
+
+// Key returns the platform folder and file name of a manifest path
+func Key(path string) string {
+	return filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+}
+
+// Find returns the manifests in the Binaries folder of dir, one level of platform folders deep, sorted
+// by path
+func Find(dir string) ([]string, error) {
+	answer, err := filepath.Glob(filepath.Join(dir, BinariesDir, "*", "*"+Extension))
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching %s for module manifests", dir)
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// LoadAll reads the manifests in the Binaries folder of every dir
+func LoadAll(dirs ...string) ([]*Manifest, error) {
+	var answer []*Manifest
+	for _, dir := range dirs {
+		paths, err := Find(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			manifest, err := Load(path)
+			if err != nil {
+				return nil, err
+			}
+			answer = append(answer, manifest)
+		}
+	}
+	return answer, nil
+}
+
+// SetBuildID writes the BuildId to the content of a .modules file, keeping its formatting
+func SetBuildID(data []byte, buildID string) ([]byte, error) {
+	if _, err := jsonedit.GetString(data, BuildIDKey); err != nil {
+		return nil, errors.Wrap(err, "the manifest has no BuildId")
+	}
+	return jsonedit.Set(data, BuildIDKey, buildID)
+}
+
+// Expected returns the BuildId each manifest key must have, taken from the first manifests given. Pass
+// the engine's manifests before the project's so the engine wins, a key nobody has a BuildId for is left
+// out.
+func Expected(manifests ...[]*Manifest) map[string]string {
+	answer := map[string]string{}
+	for _, list := range manifests {
+		for _, manifest := range list {
+			if _, ok := answer[manifest.Key()]; !ok && manifest.BuildID != "" {
+				answer[manifest.Key()] = manifest.BuildID
+			}
+		}
+	}
+	return answer
+}
@@ -0,0 +1,89 @@
+package modules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const editorModules = `{
+	"BuildId": "27405482",
+	"Modules":
+	{
+		"Shooter": "UnrealEditor-Shooter.dll"
+	}
+}`
+
+func writeManifest(t *testing.T, dir, platform, name, content string) string {
+	path := filepath.Join(dir, BinariesDir, platform, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadAll(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "Shooter")
+	plugin := filepath.Join(project, "Plugins", "Tools")
+	win := writeManifest(t, project, "Win64", "UnrealEditor.modules", editorModules)
+	writeManifest(t, project, "Linux", "UnrealEditor.modules", `{"BuildId": "1", "Modules": {}}`)
+	writeManifest(t, plugin, "Win64", "UnrealEditor.modules", `{"BuildId": "2", "Modules": {}}`)
+	writeManifest(t, project, "Win64", "Shooter.target", `{}`)
+
+	got, err := LoadAll(project, plugin)
+	assert.NoError(t, err)
+	var keys, ids []string
+	for _, manifest := range got {
+		keys = append(keys, manifest.Key())
+		ids = append(ids, manifest.BuildID)
+	}
+	assert.Equal(t, []string{"Linux/UnrealEditor.modules", "Win64/UnrealEditor.modules", "Win64/UnrealEditor.modules"}, keys)
+	assert.Equal(t, []string{"1", "27405482", "2"}, ids)
+	assert.Equal(t, win, got[1].Path)
+	assert.Equal(t, "UnrealEditor-Shooter.dll", got[1].Modules["Shooter"])
+
+	none, err := LoadAll(filepath.Join(dir, "Missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+
+	writeManifest(t, project, "Mac", "UnrealEditor.modules", `{"BuildId": }`)
+	_, err = LoadAll(project)
+	assert.Error(t, err)
+}
+
+func TestSetBuildID(t *testing.T) {
+	got, err := SetBuildID([]byte(editorModules), "29314046")
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+	"BuildId": "29314046",
+	"Modules":
+	{
+		"Shooter": "UnrealEditor-Shooter.dll"
+	}
+}`, string(got))
+
+	_, err = SetBuildID([]byte(`{"Modules": {}}`), "29314046")
+	assert.Error(t, err)
+}
+
+func TestExpected(t *testing.T) {
+	engine := []*Manifest{
+		{Path: "Engine/Binaries/Win64/UnrealEditor.modules", BuildID: "100"},
+	}
+	project := []*Manifest{
+		{Path: "Game/Binaries/Win64/UnrealEditor.modules", BuildID: "200"},
+		{Path: "Game/Binaries/Linux/UnrealEditor.modules", BuildID: "300"},
+		{Path: "Game/Binaries/Mac/UnrealEditor.modules"},
+	}
+	assert.Equal(t, map[string]string{
+		"Win64/UnrealEditor.modules": "100",
+		"Linux/UnrealEditor.modules": "300",
+	}, Expected(engine, project))
+	assert.Equal(t, map[string]string{
+		"Win64/UnrealEditor.modules": "200",
+		"Linux/UnrealEditor.modules": "300",
+	}, Expected(project))
+}
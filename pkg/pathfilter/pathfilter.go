@@ -0,0 +1,113 @@
+package pathfilter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/ryanuber/go-glob"
+)
+
+// DefaultInclude are the project files that make up the game, a change to any of them needs a new version
+var DefaultInclude = []string{"Source/*", "Content/*", "Config/*", "Plugins/*", "*.uproject"}
+
+// SkippedFolders are the folders Glob never walks into: git's own files and the build output, caches and
+// packages unreal, its editor and web tooling generate
+var SkippedFolders = []string{".git", "Binaries", "DerivedDataCache", "Intermediate", "Saved", "node_modules"}
+
+// Filter selects files by slash separated path relative to the project. In a pattern * matches any
+// text including /, and a pattern ending in / matches everything below that folder.
+type Filter struct {
+	// Include defaults to DefaultInclude when empty
+	Include []string
+	// Exclude wins over Include
+	Exclude []string
+}
+
+// Match returns true if the path is included and not excluded
+func (f *Filter) Match(path string) bool {
+	path = filepath.ToSlash(path)
+	include := f.Include
+	if len(include) == 0 {
+		include = DefaultInclude
+	}
+	return matchAny(include, path) && !matchAny(f.Exclude, path)
+}
+
+// Files returns the paths that match, in order
+func (f *Filter) Files(paths []string) []string {
+	var answer []string
+	for _, path := range paths {
+		if f.Match(path) {
+			answer = append(answer, path)
+		}
+	}
+	return answer
+}
+
+func isSkipped(name string) bool {
+	for _, skipped := range SkippedFolders {
+		if strings.EqualFold(name, skipped) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "*"
+		}
+		if glob.Glob(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Glob returns the files below root whose slash separated path relative to root matches pattern, in
+// order. Unlike filepath.Glob a * also matches /, so Docs/*.md finds markdown files in every folder
+// below Docs. Only the folder before the first * is searched, and SkippedFolders below it are skipped
+// unless the pattern names them before its first *.
+func Glob(root string, pattern string) ([]string, error) {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	if !strings.Contains(pattern, "*") {
+		path := filepath.Join(root, filepath.FromSlash(pattern))
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return nil, nil
+		}
+		return []string{path}, nil
+	}
+	prefix := pattern[:strings.Index(pattern, "*")]
+	start := filepath.Join(root, filepath.FromSlash(prefix[:strings.LastIndex(prefix, "/")+1]))
+	var answer []string
+	err := filepath.Walk(start, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			if path != start && isSkipped(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if matchAny([]string{pattern}, filepath.ToSlash(rel)) {
+			answer = append(answer, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding %s in %s", pattern, root)
+	}
+	return answer, nil
+}
@@ -0,0 +1,75 @@
+package pathfilter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		path   string
+		want   bool
+	}{
+		{"Default source", Filter{}, "Source/Game/Game.cpp", true},
+		{"Default project", Filter{}, "Game.uproject", true},
+		{"Default docs", Filter{}, "Docs/README.md", false},
+		{"Folder", Filter{Include: []string{"Source/"}}, "Source/Game/Game.h", true},
+		{"Not the folder", Filter{Include: []string{"Source/"}}, "SourceArt/Game.psd", false},
+		{"Extension", Filter{Include: []string{"*.cpp"}}, "Source/Game/Game.cpp", true},
+		{"Excluded", Filter{Exclude: []string{"*.md"}}, "Content/README.md", false},
+		{"Dot prefix", Filter{Include: []string{"./Config/*"}}, "Config/DefaultGame.ini", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.path))
+		})
+	}
+}
+
+func TestFilter_Files(t *testing.T) {
+	f := &Filter{Exclude: []string{"Content/Developers/*"}}
+	assert.Equal(t, []string{"Source/A.cpp", "Config/DefaultGame.ini"}, f.Files([]string{
+		"Source/A.cpp", "Content/Developers/me/Test.uasset", ".github/workflows/ci.yaml", "Config/DefaultGame.ini",
+	}))
+}
+
+func TestGlob(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"README.md", "Docs/Guide.md", "Docs/Old/Notes.md", ".git/HEAD.md", "Source/Game.cpp", "Saved/Logs/Log.md", "Web/node_modules/pkg/README.md", "Intermediate/Build.md"} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte("x"), 0644))
+	}
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"README.md", []string{"README.md"}},
+		{"./README.md", []string{"README.md"}},
+		{"Docs", nil},
+		{"Missing.md", nil},
+		{"Docs/*.md", []string{"Docs/Guide.md", "Docs/Old/Notes.md"}},
+		{"*.md", []string{"Docs/Guide.md", "Docs/Old/Notes.md", "README.md"}},
+		{"Missing/*.md", nil},
+		{"Saved/*.md", []string{"Saved/Logs/Log.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := Glob(root, tt.pattern)
+			assert.NoError(t, err)
+			var rel []string
+			for _, path := range got {
+				r, err := filepath.Rel(root, path)
+				assert.NoError(t, err)
+				rel = append(rel, filepath.ToSlash(r))
+			}
+			assert.Equal(t, tt.want, rel)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package rename
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectNameKey is the GeneralProjectSettings key holding the project name
+	ProjectNameKey = "ProjectName"
+	// EngineSection is the DefaultEngine.ini section holding the game name redirects
+	EngineSection = "/Script/Engine.Engine"
+	// ActiveGameNameRedirectsKey is the array of redirects from old game and module names
+	ActiveGameNameRedirectsKey = "+ActiveGameNameRedirects"
+)
+
+var (
+	nameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	wordRegex = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*\b`)
+
+	// moduleSuffixes are the module and target names derived from the project name that are renamed with it
+	moduleSuffixes = []string{"", "Editor", "Server", "Client", "Game", "Tests"}
+
+	// implementModuleRegex matches the macros registering a module, the only place C++ refers to the module name
+	implementModuleRegex = regexp.MustCompile(`IMPLEMENT_(PRIMARY_GAME_|GAME_)?MODULE\s*\([^)]*\)`)
+)
+
+// Plan plans renaming the project and the modules named after it, adding redirects so existing assets
+// keep loading
+func Plan(project *descriptor.Project, configDir string, newName string) (*edit.Plan, error) {
+	oldName := project.Name()
+	if !nameRegex.MatchString(newName) {
+		return nil, errors.Errorf("invalid project name %q, it must start with a letter and only contain letters, digits and underscores", newName)
+	}
+	if newName == oldName {
+		return nil, errors.Errorf("the project is already called %s", newName)
+	}
+	r := &renamer{
+		plan:    edit.NewPlan(),
+		project: project,
+		oldName: oldName,
+		newName: newName,
+		words:   map[string]string{},
+	}
+	for _, suffix := range moduleSuffixes {
+		r.words[oldName+suffix] = newName + suffix
+		r.words[oldName+suffix+"Target"] = newName + suffix + "Target"
+		r.words[strings.ToUpper(oldName+suffix)+"_API"] = strings.ToUpper(newName+suffix) + "_API"
+	}
+
+	if err := r.renameDescriptor(); err != nil {
+		return nil, err
+	}
+	if err := r.renameSource(); err != nil {
+		return nil, err
+	}
+	if err := r.setProjectName(configDir); err != nil {
+		return nil, err
+	}
+	if err := r.addRedirects(configDir); err != nil {
+		return nil, err
+	}
+	return r.plan, nil
+}
+
+type renamer struct {
+	plan    *edit.Plan
+	project *descriptor.Project
+	oldName string
+	newName string
+	// words maps every identifier derived from the old name to its new spelling
+	words         map[string]string
+	renamedModule []string
+}
+
+func (r *renamer) reason(what string) string {
+	return fmt.Sprintf("rename %s to %s: %s", r.oldName, r.newName, what)
+}
+
+func (r *renamer) renameDescriptor() error {
+	content := r.project.Content
+	for i, module := range r.project.Modules {
+		renamed, ok := r.words[module.Name]
+		if !ok {
+			continue
+		}
+		var err error
+		content, err = descriptor.SetField(content, fmt.Sprintf("Modules[%d].Name", i), renamed)
+		if err != nil {
+			return errors.Wrapf(err, "updating %s", r.project.Path)
+		}
+		r.renamedModule = append(r.renamedModule, module.Name)
+	}
+	if err := r.plan.Update(r.project.Path, content, r.reason("modules")); err != nil {
+		return err
+	}
+	newPath := filepath.Join(r.project.Dir(), r.newName+descriptor.ProjectExtension)
+	return r.plan.Rename(r.project.Path, newPath, r.reason("descriptor"))
+}
+
+// renameSource updates the C# rules and module registration of every source file and moves the files
+// whose folder or name is derived from the old name
+func (r *renamer) renameSource() error {
+	sourceDir := filepath.Join(r.project.Dir(), descriptor.SourceDir)
+	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := r.plan.Content(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.HasSuffix(path, ".cs"):
+			content = wordRegex.ReplaceAllFunc(content, r.replaceWord)
+		case strings.HasSuffix(path, ".h") || strings.HasSuffix(path, ".cpp"):
+			content = implementModuleRegex.ReplaceAllFunc(content, func(match []byte) []byte {
+				return wordRegex.ReplaceAllFunc(match, r.replaceWord)
+			})
+			content = wordRegex.ReplaceAllFunc(content, r.replaceAPIMacro)
+		}
+		if err := r.plan.Update(path, content, r.reason("source")); err != nil {
+			return err
+		}
+		if newPath := r.renamePath(sourceDir, path); newPath != path {
+			return r.plan.Rename(path, newPath, r.reason("move"))
+		}
+		return nil
+	})
+}
+
+func (r *renamer) replaceWord(word []byte) []byte {
+	if renamed, ok := r.words[string(word)]; ok {
+		return []byte(renamed)
+	}
+	return word
+}
+
+func (r *renamer) replaceAPIMacro(word []byte) []byte {
+	if strings.HasSuffix(string(word), "_API") {
+		return r.replaceWord(word)
+	}
+	return word
+}
+
+// renamePath renames the module folder directly under Source and the rules files named after a module
+func (r *renamer) renamePath(sourceDir string, path string) string {
+	rel, err := filepath.Rel(sourceDir, path)
+	if err != nil {
+		return path
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) > 1 {
+		if renamed, ok := r.words[parts[0]]; ok {
+			parts[0] = renamed
+		}
+	}
+	base := parts[len(parts)-1]
+	for _, suffix := range []string{".Build.cs", descriptor.TargetRulesSuffix} {
+		if strings.HasSuffix(base, suffix) {
+			if renamed, ok := r.words[strings.TrimSuffix(base, suffix)]; ok {
+				parts[len(parts)-1] = renamed + suffix
+			}
+		}
+	}
+	return filepath.Join(sourceDir, filepath.Join(parts...))
+}
+
+func (r *renamer) setProjectName(configDir string) error {
+	path, err := ueini.FindKey(configDir, ueini.GeneralProjectSettings, ProjectNameKey)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = filepath.Join(configDir, "DefaultGame.ini")
+	}
+	return r.plan.Edit(path, r.reason(ProjectNameKey), func(content []byte) ([]byte, error) {
+		return ueini.SetKey(content, ueini.GeneralProjectSettings, ProjectNameKey, r.newName)
+	})
+}
+
+// addRedirects adds ActiveGameNameRedirects so assets saved under the old game and module names still load
+func (r *renamer) addRedirects(configDir string) error {
+	redirects := []string{
+		fmt.Sprintf(`(OldGameName="%s",NewGameName="/Script/%s")`, r.oldName, r.newName),
+	}
+	for _, module := range r.renamedModule {
+		redirects = append(redirects, fmt.Sprintf(`(OldGameName="/Script/%s",NewGameName="/Script/%s")`, module, r.words[module]))
+	}
+	path := filepath.Join(configDir, "DefaultEngine.ini")
+	return r.plan.Edit(path, r.reason("redirects"), func(content []byte) ([]byte, error) {
+		cfg, err := ueini.Load(content)
+		if err != nil {
+			return nil, err
+		}
+		values := ueini.Get(cfg.Section(EngineSection), ActiveGameNameRedirectsKey)
+		for _, redirect := range redirects {
+			if !contains(values, redirect) {
+				values = append(values, redirect)
+			}
+		}
+		return ueini.SetValues(content, EngineSection, ActiveGameNameRedirectsKey, values)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package rename
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestPlan(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "Shooter.uproject"), `{
+	"FileVersion": 3,
+	"Modules": [
+		{"Name": "Shooter", "Type": "Runtime"},
+		{"Name": "ShooterEditor", "Type": "Editor"},
+		{"Name": "ShooterGameplay", "Type": "Runtime"}
+	]
+}
+`)
+	writeFile(t, filepath.Join(dir, "Config", "DefaultGame.ini"), "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Shooter\nProjectVersion=1.0.0\n")
+	writeFile(t, filepath.Join(dir, "Source", "Shooter.Target.cs"), `public class ShooterTarget : TargetRules
+{
+	public ShooterTarget(TargetInfo Target) : base(Target)
+	{
+		ExtraModuleNames.Add("Shooter");
+		ExtraModuleNames.Add("ShooterGameplay");
+	}
+}
+`)
+	writeFile(t, filepath.Join(dir, "Source", "Shooter", "Shooter.Build.cs"), "public class Shooter : ModuleRules\n{\n\tpublic Shooter(ReadOnlyTargetRules Target) : base(Target) {}\n}\n")
+	writeFile(t, filepath.Join(dir, "Source", "Shooter", "Shooter.h"), "#pragma once\nclass SHOOTER_API FShooter {};\n")
+	writeFile(t, filepath.Join(dir, "Source", "Shooter", "Shooter.cpp"), "#include \"Shooter.h\"\nIMPLEMENT_PRIMARY_GAME_MODULE(FDefaultGameModuleImpl, Shooter, \"Shooter\");\n")
+
+	project, err := descriptor.LoadProject(filepath.Join(dir, "Shooter.uproject"))
+	assert.NoError(t, err)
+
+	_, err = Plan(project, filepath.Join(dir, "Config"), "Bad Name")
+	assert.Error(t, err)
+	_, err = Plan(project, filepath.Join(dir, "Config"), "Shooter")
+	assert.Error(t, err)
+
+	plan, err := Plan(project, filepath.Join(dir, "Config"), "Blaster")
+	assert.NoError(t, err)
+	assert.NoError(t, plan.Apply())
+
+	var files []string
+	assert.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	}))
+	sort.Strings(files)
+	assert.Equal(t, []string{
+		"Blaster.uproject",
+		"Config/DefaultEngine.ini",
+		"Config/DefaultGame.ini",
+		"Source/Blaster.Target.cs",
+		"Source/Blaster/Blaster.Build.cs",
+		"Source/Blaster/Shooter.cpp",
+		"Source/Blaster/Shooter.h",
+	}, files)
+
+	assert.Contains(t, readFile(t, filepath.Join(dir, "Blaster.uproject")), `{"Name": "BlasterEditor", "Type": "Editor"}`)
+	assert.Contains(t, readFile(t, filepath.Join(dir, "Blaster.uproject")), `{"Name": "ShooterGameplay", "Type": "Runtime"}`)
+	assert.Equal(t, `public class BlasterTarget : TargetRules
+{
+	public BlasterTarget(TargetInfo Target) : base(Target)
+	{
+		ExtraModuleNames.Add("Blaster");
+		ExtraModuleNames.Add("ShooterGameplay");
+	}
+}
+`, readFile(t, filepath.Join(dir, "Source", "Blaster.Target.cs")))
+	assert.Equal(t, "public class Blaster : ModuleRules\n{\n\tpublic Blaster(ReadOnlyTargetRules Target) : base(Target) {}\n}\n", readFile(t, filepath.Join(dir, "Source", "Blaster", "Blaster.Build.cs")))
+	assert.Equal(t, "#pragma once\nclass BLASTER_API FShooter {};\n", readFile(t, filepath.Join(dir, "Source", "Blaster", "Shooter.h")))
+	assert.Equal(t, "#include \"Shooter.h\"\nIMPLEMENT_PRIMARY_GAME_MODULE(FDefaultGameModuleImpl, Blaster, \"Blaster\");\n", readFile(t, filepath.Join(dir, "Source", "Blaster", "Shooter.cpp")))
+	assert.Equal(t, "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Blaster\nProjectVersion=1.0.0\n", readFile(t, filepath.Join(dir, "Config", "DefaultGame.ini")))
+	assert.Equal(t, `[/Script/Engine.Engine]
++ActiveGameNameRedirects=(OldGameName="Shooter",NewGameName="/Script/Blaster")
++ActiveGameNameRedirects=(OldGameName="/Script/Shooter",NewGameName="/Script/Blaster")
++ActiveGameNameRedirects=(OldGameName="/Script/ShooterEditor",NewGameName="/Script/BlasterEditor")
+`, readFile(t, filepath.Join(dir, "Config", "DefaultEngine.ini")))
+}
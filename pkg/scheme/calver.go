@@ -0,0 +1,87 @@
+package scheme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// For Test Mocks
+var now = time.Now
+
+// CalVerVersion is a calendar version YYYY.MM.DD.N, N counting the builds of the day
+type CalVerVersion struct {
+	Date  time.Time
+	Build uint64
+}
+
+func (v *CalVerVersion) String() string {
+	return fmt.Sprintf("%s.%d", v.Date.Format("2006.01.02"), v.Build)
+}
+
+// CalVer is the calendar versioning scheme YYYY.MM.DD.N used for live-ops builds
+type CalVer struct{}
+
+// Name implements Scheme
+func (CalVer) Name() string {
+	return "calver"
+}
+
+// Description implements Scheme
+func (CalVer) Description() string {
+	return "YYYY.MM.DD.N, the date followed by the build of the day, such as 2024.05.17.2"
+}
+
+// Parse implements Scheme, the month and day may be written without a leading zero
+func (s CalVer) Parse(text string) (Version, error) {
+	fields := strings.Split(text, ".")
+	if len(fields) != 4 {
+		return nil, invalid(s, text, "")
+	}
+	var numbers []int
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 || strings.HasPrefix(field, "+") {
+			return nil, invalid(s, text, "")
+		}
+		numbers = append(numbers, n)
+	}
+	date := time.Date(numbers[0], time.Month(numbers[1]), numbers[2], 0, 0, 0, 0, time.UTC)
+	if len(fields[0]) != 4 || date.Year() != numbers[0] || int(date.Month()) != numbers[1] || date.Day() != numbers[2] {
+		return nil, invalid(s, text, fmt.Sprintf("%s.%s.%s is not a date", fields[0], fields[1], fields[2]))
+	}
+	return &CalVerVersion{Date: date, Build: uint64(numbers[3])}, nil
+}
+
+// Compare implements Scheme
+func (CalVer) Compare(a, b Version) int {
+	x, y := a.(*CalVerVersion), b.(*CalVerVersion)
+	switch {
+	case x.Date.Before(y.Date):
+		return -1
+	case x.Date.After(y.Date):
+		return 1
+	}
+	return compareInts(x.Build, y.Build)
+}
+
+// Parts implements Scheme
+func (CalVer) Parts() []string {
+	return []string{"build"}
+}
+
+// Bump implements Scheme, the first build of a new day is 0. A version dated in the future keeps its
+// date so the next version is always newer.
+func (s CalVer) Bump(v Version, part string) (Version, error) {
+	if _, err := partIndex(s, part); err != nil {
+		return nil, err
+	}
+	current := v.(*CalVerVersion)
+	y, m, d := now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if today.After(current.Date) {
+		return &CalVerVersion{Date: today}, nil
+	}
+	return &CalVerVersion{Date: current.Date, Build: current.Build + 1}, nil
+}
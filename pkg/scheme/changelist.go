@@ -0,0 +1,118 @@
+package scheme
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var changelistRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-(\d+))?(?:\+(.+))?$`)
+
+// ChangelistVersion is an FEngineVersion style MAJOR.MINOR.PATCH-CHANGELIST+BRANCH version. A zero
+// Changelist and an empty Branch are not written.
+type ChangelistVersion struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Changelist uint64
+	// Branch is escaped the way unreal writes it, ++UE5+Release-5.3 for //UE5/Release-5.3
+	Branch string
+}
+
+func (v *ChangelistVersion) String() string {
+	answer := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Changelist > 0 || v.Branch != "" {
+		answer += "-" + strconv.FormatUint(v.Changelist, 10)
+	}
+	if v.Branch != "" {
+		answer += "+" + v.Branch
+	}
+	return answer
+}
+
+// EscapeBranch turns a depot path such as //UE5/Release-5.3 into the ++UE5+Release-5.3 unreal uses in
+// versions
+func EscapeBranch(branch string) string {
+	return strings.ReplaceAll(branch, "/", "+")
+}
+
+// Changelist is the scheme of unreal's own engine versions such as 5.3.2-29314046+++UE5+Release-5.3
+type Changelist struct{}
+
+// Name implements Scheme
+func (Changelist) Name() string {
+	return "changelist"
+}
+
+// Description implements Scheme
+func (Changelist) Description() string {
+	return "MAJOR.MINOR.PATCH-CHANGELIST+BRANCH with optional changelist and branch such as 5.3.2-29314046+++UE5+Release-5.3"
+}
+
+// Parse implements Scheme
+func (s Changelist) Parse(text string) (Version, error) {
+	m := changelistRegex.FindStringSubmatch(text)
+	if m == nil {
+		return nil, invalid(s, text, "")
+	}
+	var numbers [4]uint64
+	for i, field := range m[1:5] {
+		if field == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, invalid(s, text, fmt.Sprintf("%s is too large", field))
+		}
+		numbers[i] = n
+	}
+	return &ChangelistVersion{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Changelist: numbers[3],
+		Branch:     m[5],
+	}, nil
+}
+
+// Compare implements Scheme, the changelist orders versions with the same numbers and the branch is
+// ignored
+func (Changelist) Compare(a, b Version) int {
+	x, y := a.(*ChangelistVersion), b.(*ChangelistVersion)
+	for _, c := range []int{
+		compareInts(x.Major, y.Major),
+		compareInts(x.Minor, y.Minor),
+		compareInts(x.Patch, y.Patch),
+		compareInts(x.Changelist, y.Changelist),
+	} {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Parts implements Scheme
+func (Changelist) Parts() []string {
+	return []string{"major", "minor", "patch"}
+}
+
+// Bump implements Scheme, the branch is kept and the changelist cleared for the caller to fill in again
+func (s Changelist) Bump(v Version, part string) (Version, error) {
+	index, err := partIndex(s, part)
+	if err != nil {
+		return nil, err
+	}
+	current := v.(*ChangelistVersion)
+	answer := &ChangelistVersion{Major: current.Major, Minor: current.Minor, Patch: current.Patch, Branch: current.Branch}
+	switch index {
+	case 0:
+		answer.Major, answer.Minor, answer.Patch = answer.Major+1, 0, 0
+	case 1:
+		answer.Minor, answer.Patch = answer.Minor+1, 0
+	default:
+		answer.Patch++
+	}
+	return answer, nil
+}
@@ -0,0 +1,142 @@
+package scheme
+
+import (
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultReleasePrefixes are the named release prefixes stripped when none are configured
+	DefaultReleasePrefixes = []string{"release-", "release/", "releases/"}
+
+	// refPrefixes are the git ref namespaces CI systems pass along with the tag or branch name
+	refPrefixes = []string{"refs/tags/", "refs/heads/"}
+)
+
+// Normalizer turns what CI passes as the version, such as refs/tags/v1.2.3, into a version of a scheme
+type Normalizer struct {
+	// ReleasePrefixes are stripped from the start of the input, ignoring case
+	ReleasePrefixes []string
+	// Extract uses a version found inside the input, such as 1.2.3 in build-1.2.3-win64, when what remains
+	// after stripping the prefixes isn't one. Otherwise that is an error.
+	Extract bool
+	// ExtractPrerelease keeps what follows the numbers of an extracted version when the scheme accepts it,
+	// such as the -rc.1 of game-1.2.3-rc.1. Otherwise only the numbers are used, a platform suffix like
+	// -win64 would make a prerelease that orders before the release.
+	ExtractPrerelease bool
+}
+
+// Normalize strips ref, release and v prefixes from raw and parses the rest, which must be a whole
+// version of the scheme
+func (n *Normalizer) Normalize(s Scheme, raw string) (Version, error) {
+	text := n.strip(raw)
+	version, err := s.Parse(text)
+	if err == nil {
+		n.log(raw, version)
+		return version, nil
+	}
+	if text != raw {
+		err = errors.Wrapf(err, "%q normalized to %q", raw, text)
+	}
+	if !n.Extract {
+		return nil, err
+	}
+	version = find(s, text, n.ExtractPrerelease)
+	if version == nil {
+		return nil, err
+	}
+	log.Logger().Warnf("%q is not a %s version, using %s found inside it", raw, s.Name(), version)
+	n.log(raw, version)
+	return version, nil
+}
+
+// Resolve returns the scheme with the name and raw normalized in it. Without a name the scheme is
+// inferred from current, unless raw isn't a version of that scheme but is one of the default scheme, such
+// as 1.2.3 set over the 1.0.0.0 of a new unreal project.
+func (n *Normalizer) Resolve(name string, current string, raw string) (Scheme, Version, error) {
+	s, err := Resolve(name, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := n.Normalize(s, raw)
+	if err == nil || name != "" || s.Name() == Default {
+		return s, version, err
+	}
+	fallback, _ := Get(Default)
+	version, fallbackErr := n.Normalize(fallback, raw)
+	if fallbackErr != nil {
+		return nil, nil, err
+	}
+	log.Logger().Infof("%q is not a %s version like the current %s, using the %s scheme", raw, s.Name(), current, fallback.Name())
+	return fallback, version, nil
+}
+
+func (n *Normalizer) log(raw string, version Version) {
+	if raw == version.String() {
+		log.Logger().Debugf("Version %s", raw)
+		return
+	}
+	log.Logger().Infof("Normalized version %q to %s", raw, utils.ColorInfo(version.String()))
+}
+
+// strip removes the prefixes in the order CI systems add them: the ref, then the release name, then v
+func (n *Normalizer) strip(raw string) string {
+	text := strings.TrimSpace(raw)
+	if strings.HasPrefix(text, "refs/remotes/") {
+		parts := strings.SplitN(text, "/", 4)
+		if len(parts) == 4 {
+			text = parts[3]
+		}
+	}
+	for _, prefix := range refPrefixes {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	prefixes := n.ReleasePrefixes
+	if prefixes == nil {
+		prefixes = DefaultReleasePrefixes
+	}
+	for _, prefix := range prefixes {
+		if len(text) > len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+			text = text[len(prefix):]
+			break
+		}
+	}
+	if len(text) > 1 && (text[0] == 'v' || text[0] == 'V') && isDigit(text[1]) {
+		text = text[1:]
+	}
+	return text
+}
+
+// find returns the longest version starting at the first position that has one, such as 1.2.3 in
+// game-1.2.3-win64. A version must not be followed by more numbers, 1.0.0.1 doesn't hold 1.0.0. Without
+// prerelease the version is made of digits and dots only.
+func find(s Scheme, text string, prerelease bool) Version {
+	for start := 0; start < len(text); start++ {
+		if !isDigit(text[start]) || (start > 0 && (isDigit(text[start-1]) || text[start-1] == '.')) {
+			continue
+		}
+		last := len(text)
+		if !prerelease {
+			last = start
+			for last < len(text) && (isDigit(text[last]) || text[last] == '.') {
+				last++
+			}
+		}
+		for end := last; end > start; end-- {
+			if end < len(text) && (isDigit(text[end]) || text[end] == '.') {
+				continue
+			}
+			if version, err := s.Parse(text[start:end]); err == nil {
+				return version
+			}
+		}
+	}
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,76 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer Normalizer
+		scheme     string
+		raw        string
+		want       string
+		wantErr    bool
+	}{
+		{"Plain", Normalizer{}, "semver", "1.2.3", "1.2.3", false},
+		{"Tag name", Normalizer{}, "semver", "v1.2.3", "1.2.3", false},
+		{"Tag ref", Normalizer{}, "semver", "refs/tags/v1.2.3", "1.2.3", false},
+		{"Branch ref", Normalizer{}, "semver", "refs/heads/release/1.2.3", "1.2.3", false},
+		{"Remote ref", Normalizer{}, "semver", "refs/remotes/origin/release-1.2.3", "1.2.3", false},
+		{"Release prefix", Normalizer{}, "semver", "release-1.2.3", "1.2.3", false},
+		{"Release prefix with v", Normalizer{}, "semver", "Release-v1.2.3", "1.2.3", false},
+		{"Configured prefix", Normalizer{ReleasePrefixes: []string{"shooter-"}}, "semver", "refs/tags/shooter-v2.0.0", "2.0.0", false},
+		{"Whitespace", Normalizer{}, "four-part", " 1.2.3.4\n", "1.2.3.4", false},
+		{"Embedded", Normalizer{}, "semver", "game-1.2.3", "", true},
+		{"Extracted", Normalizer{Extract: true}, "semver", "game-1.2.3", "1.2.3", false},
+		{"Platform suffix", Normalizer{Extract: true}, "semver", "MyGame-1.2.3-win64", "1.2.3", false},
+		{"Prerelease", Normalizer{Extract: true}, "semver", "MyGame-1.2.3-rc.1", "1.2.3", false},
+		{"Extracted prerelease", Normalizer{Extract: true, ExtractPrerelease: true}, "semver", "MyGame-1.2.3-rc.1", "1.2.3-rc.1", false},
+		{"Extracted with prefixes", Normalizer{Extract: true}, "semver", "refs/tags/v1.2.3", "1.2.3", false},
+		{"Four parts", Normalizer{}, "semver", "1.0.0.1", "", true},
+		{"Four parts extracted", Normalizer{Extract: true}, "semver", "1.0.0.1", "", true},
+		{"Trailing number", Normalizer{Extract: true}, "semver", "build-1.2.3.4-win64", "", true},
+		{"No version", Normalizer{}, "semver", "latest", "", true},
+		{"Version word", Normalizer{}, "semver", "version", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Get(tt.scheme)
+			assert.NoError(t, err)
+			got, err := tt.normalizer.Normalize(s, tt.raw)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestNormalizer_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		raw        string
+		wantScheme string
+		want       string
+		wantErr    bool
+	}{
+		{"", "1.0.0.0", "1.0.0.1", "four-part", "1.0.0.1", false},
+		{"", "1.0.0.0", "v1.2.3", Default, "1.2.3", false},
+		{"", "1.0.0", "1.2.3", Default, "1.2.3", false},
+		{"", "1.0.0.0", "latest", "", "", true},
+		{"four-part", "1.0.0.0", "1.2.3", "", "", true},
+		{"semver", "1.0.0.0", "1.2.3", "semver", "1.2.3", false},
+	}
+	for _, tt := range tests {
+		s, version, err := (&Normalizer{}).Resolve(tt.name, tt.current, tt.raw)
+		assert.Equal(t, tt.wantErr, err != nil, "%s %q %q", tt.name, tt.current, tt.raw)
+		if err == nil {
+			assert.Equal(t, tt.wantScheme, s.Name())
+			assert.Equal(t, tt.want, version.String())
+		}
+	}
+}
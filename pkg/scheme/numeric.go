@@ -0,0 +1,110 @@
+package scheme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// FourPart is the Windows file version scheme MAJOR.MINOR.BUILD.REVISION, unreal's default
+	// ProjectVersion is 1.0.0.0
+	FourPart = &Numeric{
+		name:        "four-part",
+		description: "MAJOR.MINOR.BUILD.REVISION with each part from 0 to 65535 such as 1.2.3.4",
+		parts:       []string{"major", "minor", "build", "revision"},
+		max:         65535,
+	}
+
+	// Integer is a single increasing build number
+	Integer = &Numeric{
+		name:        "integer",
+		description: "a whole number such as 42",
+		parts:       []string{"build"},
+		max:         1<<63 - 1,
+	}
+)
+
+// NumericVersion is a version made of a fixed number of dot separated numbers
+type NumericVersion []uint64
+
+func (v NumericVersion) String() string {
+	var parts []string
+	for _, n := range v {
+		parts = append(parts, strconv.FormatUint(n, 10))
+	}
+	return strings.Join(parts, ".")
+}
+
+// Numeric is a scheme of dot separated numbers compared from left to right
+type Numeric struct {
+	name        string
+	description string
+	parts       []string
+	max         uint64
+}
+
+// Name implements Scheme
+func (s *Numeric) Name() string {
+	return s.name
+}
+
+// Description implements Scheme
+func (s *Numeric) Description() string {
+	return s.description
+}
+
+// Parse implements Scheme
+func (s *Numeric) Parse(text string) (Version, error) {
+	fields := strings.Split(text, ".")
+	if len(fields) != len(s.parts) {
+		return nil, invalid(s, text, fmt.Sprintf("it has %d parts instead of %d", len(fields), len(s.parts)))
+	}
+	var answer NumericVersion
+	for i, field := range fields {
+		if field == "" || strings.TrimLeft(field, "0123456789") != "" {
+			return nil, invalid(s, text, fmt.Sprintf("the %s is not a number", s.parts[i]))
+		}
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || n > s.max {
+			return nil, invalid(s, text, fmt.Sprintf("the %s is larger than %d", s.parts[i], s.max))
+		}
+		answer = append(answer, n)
+	}
+	return answer, nil
+}
+
+// Compare implements Scheme
+func (s *Numeric) Compare(a, b Version) int {
+	x, y := a.(NumericVersion), b.(NumericVersion)
+	for i := range x {
+		if c := compareInts(x[i], y[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Parts implements Scheme
+func (s *Numeric) Parts() []string {
+	return s.parts
+}
+
+// Bump implements Scheme
+func (s *Numeric) Bump(v Version, part string) (Version, error) {
+	index, err := partIndex(s, part)
+	if err != nil {
+		return nil, err
+	}
+	answer := append(NumericVersion{}, v.(NumericVersion)...)
+	if answer[index] == s.max {
+		return nil, errors.Errorf("cannot bump the %s of %s, it is already %d", s.parts[index], v, s.max)
+	}
+	answer[index]++
+	for i := index + 1; i < len(answer); i++ {
+		answer[i] = 0
+	}
+	return answer, nil
+}
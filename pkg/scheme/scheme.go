@@ -0,0 +1,125 @@
+package scheme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/pkg/errors"
+)
+
+// Default is the scheme used when neither the flag nor the config file choose one and the current
+// version doesn't tell, see Infer
+const Default = "semver"
+
+// Version is a parsed version, String formats it the way it is written to the project
+type Version interface {
+	String() string
+}
+
+// Scheme parses, validates, orders and bumps the versions of one format
+type Scheme interface {
+	// Name selects the scheme in the --scheme flag and the config file
+	Name() string
+	// Description explains the format in help and error messages
+	Description() string
+	// Parse validates s and returns the version it describes
+	Parse(s string) (Version, error)
+	// Compare returns -1, 0 or 1 when a is older than, the same as or newer than b
+	Compare(a, b Version) int
+	// Parts lists the parts Bump accepts from the most significant, an empty part bumps the last one
+	Parts() []string
+	// Bump returns the version after v, increasing part and resetting the parts after it
+	Bump(v Version, part string) (Version, error)
+}
+
+var schemes = []Scheme{
+	SemVer{},
+	CalVer{},
+	FourPart,
+	Integer,
+	Changelist{},
+}
+
+// Names returns the name of every scheme
+func Names() []string {
+	var answer []string
+	for _, s := range schemes {
+		answer = append(answer, s.Name())
+	}
+	return answer
+}
+
+// Get returns the scheme with the name, the default scheme for an empty name
+func Get(name string) (Scheme, error) {
+	if name == "" {
+		name = Default
+	}
+	for _, s := range schemes {
+		if strings.EqualFold(s.Name(), name) {
+			return s, nil
+		}
+	}
+	message := fmt.Sprintf("unknown version scheme %q, expected one of %s", name, strings.Join(Names(), ", "))
+	if suggestions := utils.Suggestions(name, Names()); len(suggestions) > 0 {
+		message = fmt.Sprintf("unknown version scheme %q, did you mean %s?", name, strings.Join(suggestions, " or "))
+	}
+	return nil, errors.New(message)
+}
+
+// Resolve returns the scheme with the name. Without a name the scheme is inferred from the current
+// version: four numbers, such as the 1.0.0.0 of a new unreal project, are four-part, anything else is
+// the default scheme.
+func Resolve(name string, current string) (Scheme, error) {
+	if name == "" {
+		return Infer(current), nil
+	}
+	return Get(name)
+}
+
+// Infer returns the scheme of a version when none is configured
+func Infer(current string) Scheme {
+	if _, err := FourPart.Parse(strings.TrimSpace(current)); err == nil {
+		return FourPart
+	}
+	answer, _ := Get(Default)
+	return answer
+}
+
+// Validate returns an error describing the scheme if s is not a valid version
+func Validate(scheme Scheme, s string) error {
+	_, err := scheme.Parse(s)
+	return err
+}
+
+// invalid returns the error for a string that doesn't match the scheme
+func invalid(scheme Scheme, s string, reason string) error {
+	if reason != "" {
+		reason = ", " + reason
+	}
+	return errors.Errorf("%q is not a valid %s version%s, expected %s", s, scheme.Name(), reason, scheme.Description())
+}
+
+// partIndex returns the position of part in the scheme's parts, the last one for an empty part
+func partIndex(scheme Scheme, part string) (int, error) {
+	parts := scheme.Parts()
+	if part == "" {
+		return len(parts) - 1, nil
+	}
+	for i, p := range parts {
+		if strings.EqualFold(p, part) {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("cannot bump %q of a %s version, expected one of %s", part, scheme.Name(), strings.Join(parts, ", "))
+}
+
+func compareInts(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,175 @@
+package scheme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	s, err := Get("")
+	assert.NoError(t, err)
+	assert.Equal(t, Default, s.Name())
+
+	s, err = Get("CalVer")
+	assert.NoError(t, err)
+	assert.Equal(t, "calver", s.Name())
+
+	_, err = Get("fourpart")
+	assert.EqualError(t, err, `unknown version scheme "fourpart", did you mean four-part?`)
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		want    string
+	}{
+		{"", "1.0.0.0", "four-part"},
+		{"", " 2.1.0.17\n", "four-part"},
+		{"", "1.0.0", Default},
+		{"", "", Default},
+		{"", "1.0.0.0.0", Default},
+		{"semver", "1.0.0.0", "semver"},
+		{"calver", "", "calver"},
+	}
+	for _, tt := range tests {
+		s, err := Resolve(tt.name, tt.current)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, s.Name(), "%s %q", tt.name, tt.current)
+	}
+	_, err := Resolve("fourpart", "1.0.0.0")
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"semver", "1.2.3", "1.2.3", false},
+		{"semver", "1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5", false},
+		{"semver", "1.2", "", true},
+		{"semver", "01.2.3", "", true},
+		{"semver", "v1.2.3", "", true},
+		{"calver", "2024.05.17.2", "2024.05.17.2", false},
+		{"calver", "2024.5.7.0", "2024.05.07.0", false},
+		{"calver", "2024.02.30.1", "", true},
+		{"calver", "24.05.17.1", "", true},
+		{"calver", "2024.05.17", "", true},
+		{"four-part", "1.0.0.0", "1.0.0.0", false},
+		{"four-part", "1.2.3.65535", "1.2.3.65535", false},
+		{"four-part", "1.2.3.65536", "", true},
+		{"four-part", "1.2.3", "", true},
+		{"four-part", "1.2.-3.4", "", true},
+		{"integer", "42", "42", false},
+		{"integer", "4.2", "", true},
+		{"integer", "", "", true},
+		{"changelist", "5.3.2-29314046+++UE5+Release-5.3", "5.3.2-29314046+++UE5+Release-5.3", false},
+		{"changelist", "1.2.3-42", "1.2.3-42", false},
+		{"changelist", "1.2.3", "1.2.3", false},
+		{"changelist", "1.2.3+++Game+Main", "1.2.3-0+++Game+Main", false},
+		{"changelist", "1.2", "", true},
+		{"changelist", "1.2.3-rc1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme+" "+tt.text, func(t *testing.T) {
+			s, err := Get(tt.scheme)
+			assert.NoError(t, err)
+			v, err := s.Parse(tt.text)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tt.want, v.String())
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		scheme string
+		a, b   string
+		want   int
+	}{
+		{"semver", "1.2.3", "1.2.3", 0},
+		{"semver", "1.2.3", "1.10.0", -1},
+		{"semver", "2.0.0", "2.0.0-rc.1", 1},
+		{"semver", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"semver", "1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"semver", "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"semver", "1.0.0-1", "1.0.0-alpha", -1},
+		{"semver", "1.0.0+a", "1.0.0+b", 0},
+		{"calver", "2024.05.17.2", "2024.05.17.10", -1},
+		{"calver", "2024.06.01.0", "2024.05.31.9", 1},
+		{"four-part", "1.2.3.10", "1.2.3.9", 1},
+		{"integer", "9", "10", -1},
+		{"changelist", "5.3.2-100+++UE5+Main", "5.3.2-99+++UE5+Release-5.3", 1},
+		{"changelist", "5.3.2-100", "5.3.2-100+++UE5+Main", 0},
+		{"changelist", "5.4.0-1", "5.3.2-100", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme+" "+tt.a+" "+tt.b, func(t *testing.T) {
+			s, err := Get(tt.scheme)
+			assert.NoError(t, err)
+			a, err := s.Parse(tt.a)
+			assert.NoError(t, err)
+			b, err := s.Parse(tt.b)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, s.Compare(a, b))
+			assert.Equal(t, -tt.want, s.Compare(b, a))
+		})
+	}
+}
+
+func TestBump(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 5, 17, 15, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { now = time.Now })
+
+	tests := []struct {
+		scheme  string
+		version string
+		part    string
+		want    string
+		wantErr bool
+	}{
+		{"semver", "1.2.3", "", "1.2.4", false},
+		{"semver", "1.2.3+build", "minor", "1.3.0", false},
+		{"semver", "1.2.3", "major", "2.0.0", false},
+		{"semver", "1.2.3-rc.1", "patch", "1.2.3", false},
+		{"semver", "2.0.0-rc.1", "major", "2.0.0", false},
+		{"semver", "2.1.0-rc.1", "major", "3.0.0", false},
+		{"semver", "1.2.3", "build", "", true},
+		{"calver", "2024.05.16.3", "", "2024.05.17.0", false},
+		{"calver", "2024.05.17.3", "build", "2024.05.17.4", false},
+		{"calver", "2024.05.18.0", "", "2024.05.18.1", false},
+		{"four-part", "1.2.3.4", "", "1.2.3.5", false},
+		{"four-part", "1.2.3.4", "minor", "1.3.0.0", false},
+		{"four-part", "1.2.3.65535", "revision", "", true},
+		{"integer", "41", "", "42", false},
+		{"changelist", "5.3.2-29314046+++UE5+Release-5.3", "", "5.3.3-0+++UE5+Release-5.3", false},
+		{"changelist", "5.3.2-29314046", "minor", "5.4.0", false},
+		{"changelist", "5.3.2", "changelist", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme+" "+tt.version+" "+tt.part, func(t *testing.T) {
+			s, err := Get(tt.scheme)
+			assert.NoError(t, err)
+			v, err := s.Parse(tt.version)
+			assert.NoError(t, err)
+			got, err := s.Bump(v, tt.part)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tt.want, got.String())
+				assert.Equal(t, 1, s.Compare(got, v))
+			}
+		})
+	}
+}
+
+func TestEscapeBranch(t *testing.T) {
+	assert.Equal(t, "++UE5+Release-5.3", EscapeBranch("//UE5/Release-5.3"))
+	assert.Equal(t, "++Game+Main", EscapeBranch("++Game+Main"))
+}
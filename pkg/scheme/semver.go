@@ -0,0 +1,140 @@
+package scheme
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverRegex is the regular expression recommended by semver.org
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// SemVerVersion is a semantic version such as 1.2.3-rc.1+build.5
+type SemVerVersion struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      string
+}
+
+func (v *SemVerVersion) String() string {
+	answer := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		answer += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		answer += "+" + v.Build
+	}
+	return answer
+}
+
+// SemVer is the semantic versioning scheme, https://semver.org
+type SemVer struct{}
+
+// Name implements Scheme
+func (SemVer) Name() string {
+	return "semver"
+}
+
+// Description implements Scheme
+func (SemVer) Description() string {
+	return "MAJOR.MINOR.PATCH with an optional -prerelease and +build such as 1.2.3 or 2.0.0-rc.1"
+}
+
+// Parse implements Scheme
+func (s SemVer) Parse(text string) (Version, error) {
+	groups := semverRegex.FindStringSubmatch(text)
+	if groups == nil {
+		return nil, invalid(s, text, "")
+	}
+	answer := &SemVerVersion{Build: groups[5]}
+	for i, n := range []*uint64{&answer.Major, &answer.Minor, &answer.Patch} {
+		value, err := strconv.ParseUint(groups[i+1], 10, 64)
+		if err != nil {
+			return nil, invalid(s, text, "a number is too large")
+		}
+		*n = value
+	}
+	if groups[4] != "" {
+		answer.Prerelease = strings.Split(groups[4], ".")
+	}
+	return answer, nil
+}
+
+// Compare implements Scheme using semver precedence, build metadata is ignored
+func (SemVer) Compare(a, b Version) int {
+	x, y := a.(*SemVerVersion), b.(*SemVerVersion)
+	for _, c := range []int{compareInts(x.Major, y.Major), compareInts(x.Minor, y.Minor), compareInts(x.Patch, y.Patch)} {
+		if c != 0 {
+			return c
+		}
+	}
+	// a version without prerelease is newer than any prerelease of it
+	switch {
+	case len(x.Prerelease) == 0 && len(y.Prerelease) == 0:
+		return 0
+	case len(x.Prerelease) == 0:
+		return 1
+	case len(y.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(x.Prerelease) && i < len(y.Prerelease); i++ {
+		if c := comparePrerelease(x.Prerelease[i], y.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(uint64(len(x.Prerelease)), uint64(len(y.Prerelease)))
+}
+
+// comparePrerelease orders numeric identifiers numerically and before alphanumeric ones
+func comparePrerelease(a, b string) int {
+	x, errX := strconv.ParseUint(a, 10, 64)
+	y, errY := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errX == nil && errY == nil:
+		return compareInts(x, y)
+	case errX == nil:
+		return -1
+	case errY == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Parts implements Scheme
+func (SemVer) Parts() []string {
+	return []string{"major", "minor", "patch"}
+}
+
+// Bump implements Scheme. Bumping a prerelease releases it when the parts after the bumped one are zero,
+// so 2.0.0-rc.1 bumped by major is 2.0.0.
+func (s SemVer) Bump(v Version, part string) (Version, error) {
+	index, err := partIndex(s, part)
+	if err != nil {
+		return nil, err
+	}
+	current := v.(*SemVerVersion)
+	answer := &SemVerVersion{Major: current.Major, Minor: current.Minor, Patch: current.Patch}
+	released := len(current.Prerelease) > 0
+	switch index {
+	case 0:
+		if !released || current.Minor != 0 || current.Patch != 0 {
+			answer.Major++
+		}
+		answer.Minor, answer.Patch = 0, 0
+	case 1:
+		if !released || current.Patch != 0 {
+			answer.Minor++
+		}
+		answer.Patch = 0
+	case 2:
+		if !released {
+			answer.Patch++
+		}
+	}
+	return answer, nil
+}
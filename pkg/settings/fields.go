@@ -0,0 +1,274 @@
+package settings
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+// Kind is the unreal type of a settings field, deciding how its value is validated and written
+type Kind string
+
+const (
+	KindString Kind = "string"
+	KindText   Kind = "text"
+	KindGUID   Kind = "guid"
+	KindBool   Kind = "bool"
+	KindFloat  Kind = "float"
+)
+
+var (
+	textRegex          = regexp.MustCompile(`^(NSLOCTEXT|LOCTEXT|INVTEXT)\((.*)\)$`)
+	stringLiteralRegex = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+	fields = reflectFields()
+)
+
+// Field is one key of GeneralProjectSettings
+type Field struct {
+	Name  string
+	Kind  Kind
+	index int
+}
+
+func reflectFields() []Field {
+	var answer []Field
+	t := reflect.TypeOf(GeneralProjectSettings{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		kind := KindString
+		switch {
+		case field.Type == reflect.TypeOf(Text("")):
+			kind = KindText
+		case field.Type == reflect.TypeOf(GUID("")):
+			kind = KindGUID
+		case field.Type.Kind() == reflect.Bool:
+			kind = KindBool
+		case field.Type.Kind() == reflect.Float64:
+			kind = KindFloat
+		}
+		answer = append(answer, Field{Name: field.Tag.Get("ini"), Kind: kind, index: i})
+	}
+	return answer
+}
+
+// Fields returns every field of GeneralProjectSettings in declaration order
+func Fields() []Field {
+	return fields
+}
+
+// FieldNames returns the ini key of every field
+func FieldNames() []string {
+	var answer []string
+	for _, field := range fields {
+		answer = append(answer, field.Name)
+	}
+	return answer
+}
+
+// LookupField finds a field by its ini key, ignoring case and the `b` prefix of booleans
+func LookupField(name string) (Field, error) {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) || (field.Kind == KindBool && strings.EqualFold(field.Name, "b"+name)) {
+			return field, nil
+		}
+	}
+	message := fmt.Sprintf("unknown GeneralProjectSettings field %q", name)
+	if suggestions := utils.Suggestions(name, FieldNames()); len(suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %s?", strings.Join(suggestions, " or "))
+	}
+	return Field{}, errors.New(message)
+}
+
+// Decode turns the raw ini value into the value a person would type
+func (f Field) Decode(raw string) (string, error) {
+	switch f.Kind {
+	case KindText:
+		return decodeText(raw), nil
+	case KindBool:
+		b, err := strconv.ParseBool(strings.ToLower(raw))
+		if err != nil {
+			return "", errors.Errorf("%s: %q is not a boolean", f.Name, raw)
+		}
+		return strconv.FormatBool(b), nil
+	}
+	return unquote(raw), nil
+}
+
+// Encode validates a value given on the command line and returns it the way unreal writes it. The
+// current raw value is used to keep the namespace and key of localized text.
+func (f Field) Encode(value string, current string) (string, error) {
+	switch f.Kind {
+	case KindText:
+		return encodeText(value, current), nil
+	case KindGUID:
+		if err := ValidateProjectID(GUID(value)); err != nil {
+			return "", err
+		}
+		return strings.ToUpper(value), nil
+	case KindBool:
+		b, err := parseBool(value)
+		if err != nil {
+			return "", errors.Errorf("%s: %q is not a boolean, use true or false", f.Name, value)
+		}
+		if b {
+			return "True", nil
+		}
+		return "False", nil
+	case KindFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", errors.Errorf("%s: %q is not a number", f.Name, value)
+		}
+		return strconv.FormatFloat(v, 'f', 6, 64), nil
+	}
+	return quote(value), nil
+}
+
+// Get returns the field's value from the settings formatted as text
+func (f Field) Get(s *GeneralProjectSettings) string {
+	value := reflect.ValueOf(s).Elem().Field(f.index)
+	switch f.Kind {
+	case KindBool:
+		return strconv.FormatBool(value.Bool())
+	case KindFloat:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	}
+	return value.String()
+}
+
+// set stores a raw ini value into the settings
+func (f Field) set(s *GeneralProjectSettings, raw string) error {
+	decoded, err := f.Decode(raw)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(s).Elem().Field(f.index)
+	switch f.Kind {
+	case KindBool:
+		b, _ := strconv.ParseBool(decoded)
+		value.SetBool(b)
+	case KindFloat:
+		v, err := strconv.ParseFloat(decoded, 64)
+		if err != nil {
+			return errors.Errorf("%s: %q is not a number", f.Name, raw)
+		}
+		value.SetFloat(v)
+	default:
+		value.SetString(decoded)
+	}
+	return nil
+}
+
+// Assignment is a value to write to a field
+type Assignment struct {
+	Field string
+	Value string
+}
+
+// ParseAssignments reads either `<field> <value>` or any number of `<field>=<value>` arguments
+func ParseAssignments(args []string) ([]Assignment, error) {
+	if len(args) == 2 && !strings.Contains(args[0], "=") {
+		return []Assignment{{Field: args[0], Value: args[1]}}, nil
+	}
+	var answer []Assignment
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expected <field>=<value> but got %q", arg)
+		}
+		answer = append(answer, Assignment{Field: parts[0], Value: parts[1]})
+	}
+	if len(answer) == 0 {
+		return nil, errors.New("no fields to set")
+	}
+	return answer, nil
+}
+
+// Apply returns the config content with every assignment written. Every value is validated before
+// anything changes, so either all fields are set or an error is returned.
+func Apply(content []byte, assignments []Assignment) ([]byte, error) {
+	cfg, err := ueini.Load(content)
+	if err != nil {
+		return nil, err
+	}
+	section := cfg.Section(ueini.GeneralProjectSettings)
+	for _, assignment := range assignments {
+		field, err := LookupField(assignment.Field)
+		if err != nil {
+			return nil, err
+		}
+		current := ""
+		if section.HasKey(field.Name) {
+			current = section.Key(field.Name).String()
+		}
+		encoded, err := field.Encode(assignment.Value, current)
+		if err != nil {
+			return nil, err
+		}
+		section.Key(field.Name).SetValue(encoded)
+	}
+	return ueini.Bytes(cfg)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	return strconv.ParseBool(strings.ToLower(value))
+}
+
+func decodeText(raw string) string {
+	groups := textRegex.FindStringSubmatch(raw)
+	if groups == nil {
+		return unquote(raw)
+	}
+	literals := stringLiteralRegex.FindAllStringSubmatch(groups[2], -1)
+	if len(literals) == 0 {
+		return ""
+	}
+	return unescape(literals[len(literals)-1][1])
+}
+
+func encodeText(value string, current string) string {
+	groups := textRegex.FindStringSubmatch(current)
+	if groups != nil && groups[1] != "INVTEXT" {
+		literals := stringLiteralRegex.FindAllString(groups[2], -1)
+		if len(literals) == 3 {
+			return fmt.Sprintf(`%s(%s, %s, "%s")`, groups[1], literals[0], literals[1], escape(value))
+		}
+	}
+	return fmt.Sprintf(`INVTEXT("%s")`, escape(value))
+}
+
+// quote wraps strings that wouldn't survive being written bare, the way unreal exports them
+func quote(value string) string {
+	if value == "" || (value == strings.TrimSpace(value) && !strings.ContainsAny(value, "\"\n\r\\")) {
+		return value
+	}
+	return `"` + escape(value) + `"`
+}
+
+func unquote(raw string) string {
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		return unescape(raw[1 : len(raw)-1])
+	}
+	return raw
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\r`, "\r").Replace(value)
+}
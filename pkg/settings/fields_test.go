@@ -0,0 +1,106 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupField(t *testing.T) {
+	field, err := LookupField("projectversion")
+	assert.NoError(t, err)
+	assert.Equal(t, "ProjectVersion", field.Name)
+	assert.Equal(t, KindString, field.Kind)
+
+	field, err = LookupField("AllowClose")
+	assert.NoError(t, err)
+	assert.Equal(t, "bAllowClose", field.Name)
+	assert.Equal(t, KindBool, field.Kind)
+
+	field, err = LookupField("ProjectDisplayedTitle")
+	assert.NoError(t, err)
+	assert.Equal(t, KindText, field.Kind)
+
+	_, err = LookupField("ProjectVerison")
+	assert.EqualError(t, err, `unknown GeneralProjectSettings field "ProjectVerison", did you mean ProjectVersion?`)
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		value   string
+		current string
+		want    string
+		wantErr bool
+	}{
+		{"String", "CompanyName", "Epic Games", "", "Epic Games", false},
+		{"String with quotes", "Description", `A "fun" game`, "", `"A \"fun\" game"`, false},
+		{"String with padding", "Description", " padded ", "", `" padded "`, false},
+		{"Bool", "bAllowClose", "yes", "", "True", false},
+		{"Bool false", "bAllowClose", "0", "", "False", false},
+		{"Bool invalid", "bAllowClose", "maybe", "", "", true},
+		{"Float", "FOVForFakeStereoRenderingDevice", "90", "", "90.000000", false},
+		{"Float invalid", "FOVForFakeStereoRenderingDevice", "wide", "", "", true},
+		{"GUID", "ProjectID", "0c9a1b2c4d5e6f708192a3b4c5d6e7f8", "", "0C9A1B2C4D5E6F708192A3B4C5D6E7F8", false},
+		{"GUID invalid", "ProjectID", "1234", "", "", true},
+		{"Text", "ProjectDisplayedTitle", "Shooter", "", `INVTEXT("Shooter")`, false},
+		{"Text keeps localization key", "ProjectDisplayedTitle", "Shooter 2", `NSLOCTEXT("[/Script/EngineSettings]", "6B4D3A", "Shooter")`, `NSLOCTEXT("[/Script/EngineSettings]", "6B4D3A", "Shooter 2")`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := LookupField(tt.field)
+			assert.NoError(t, err)
+			got, err := field.Encode(tt.value, tt.current)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		field string
+		raw   string
+		want  string
+	}{
+		{"Description", `"A \"fun\" game"`, `A "fun" game`},
+		{"Description", "plain", "plain"},
+		{"ProjectDisplayedTitle", `NSLOCTEXT("[/Script/EngineSettings]", "6B4D3A", "Shooter")`, "Shooter"},
+		{"ProjectDisplayedTitle", `INVTEXT("Shooter")`, "Shooter"},
+		{"bAllowClose", "True", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			field, err := LookupField(tt.field)
+			assert.NoError(t, err)
+			got, err := field.Decode(tt.raw)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	content := []byte("[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Shooter\n")
+
+	got, err := Apply(content, []Assignment{{"CompanyName", "Studio"}, {"AllowClose", "false"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "[/Script/EngineSettings.GeneralProjectSettings]\nProjectName=Shooter\nCompanyName=Studio\nbAllowClose=False\n", string(got))
+
+	_, err = Apply(content, []Assignment{{"CompanyName", "Studio"}, {"ProjectID", "nope"}})
+	assert.Error(t, err)
+}
+
+func TestParseAssignments(t *testing.T) {
+	got, err := ParseAssignments([]string{"CompanyName", "Epic Games"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Assignment{{"CompanyName", "Epic Games"}}, got)
+
+	got, err = ParseAssignments([]string{"CompanyName=Epic", "Homepage=https://a.b/?x=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Assignment{{"CompanyName", "Epic"}, {"Homepage", "https://a.b/?x=1"}}, got)
+
+	_, err = ParseAssignments([]string{"CompanyName=Epic", "Homepage"})
+	assert.Error(t, err)
+}
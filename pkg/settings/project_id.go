@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/pkg/errors"
+)
+
+// skipDirs are folders that never hold projects of their own but can be very large
+var skipDirs = map[string]bool{
+	".git":             true,
+	"Binaries":         true,
+	"DerivedDataCache": true,
+	"Intermediate":     true,
+	"Saved":            true,
+	"Content":          true,
+	"node_modules":     true,
+}
+
+// ProjectIdentity is a project found in a repository together with its ProjectID
+type ProjectIdentity struct {
+	Project   string
+	ProjectID GUID
+}
+
+// FindProjectIDs returns the ProjectID of every .uproject below root, sorted by path
+func FindProjectIDs(root string) ([]ProjectIdentity, error) {
+	var answer []ProjectIdentity
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if skipDirs[info.Name()] && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != descriptor.ProjectExtension {
+			return nil
+		}
+		file, err := Find(filepath.Join(filepath.Dir(path), "Config"))
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				answer = append(answer, ProjectIdentity{Project: path})
+				return nil
+			}
+			return err
+		}
+		settings, err := Load(file)
+		if err != nil {
+			return err
+		}
+		answer = append(answer, ProjectIdentity{Project: path, ProjectID: settings.ProjectID})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching %s for projects", root)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Project < answer[j].Project
+	})
+	return answer, nil
+}
+
+// DuplicateProjectIDs groups the projects sharing a ProjectID, keyed by the upper cased ID
+func DuplicateProjectIDs(projects []ProjectIdentity) map[string][]string {
+	byID := map[string][]string{}
+	for _, project := range projects {
+		if project.ProjectID == "" {
+			continue
+		}
+		id := strings.ToUpper(string(project.ProjectID))
+		byID[id] = append(byID[id], project.Project)
+	}
+	for id, paths := range byID {
+		if len(paths) < 2 {
+			delete(byID, id)
+		}
+	}
+	return byID
+}
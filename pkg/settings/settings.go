@@ -0,0 +1,108 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/utils"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectIDKey is the key holding the project's GUID
+	ProjectIDKey = "ProjectID"
+
+	// DefaultGameIni is the file unreal saves GeneralProjectSettings to
+	DefaultGameIni = "DefaultGame.ini"
+)
+
+var projectIDRegex = regexp.MustCompile(`^[0-9A-Fa-f]{32}$`)
+
+// Text is a localizable FText value, written to ini files as NSLOCTEXT(...) or INVTEXT(...)
+type Text string
+
+// GUID is an FGuid value written as 32 hex digits
+type GUID string
+
+// GeneralProjectSettings is the /Script/EngineSettings.GeneralProjectSettings section of the project config,
+// every field of UGeneralProjectSettings with the Go type matching its unreal type
+type GeneralProjectSettings struct {
+	CompanyName                           string  `json:"CompanyName" ini:"CompanyName"`
+	CompanyDistinguishedName              string  `json:"CompanyDistinguishedName" ini:"CompanyDistinguishedName"`
+	CopyrightNotice                       string  `json:"CopyrightNotice" ini:"CopyrightNotice"`
+	Description                           string  `json:"Description" ini:"Description"`
+	Homepage                              string  `json:"Homepage" ini:"Homepage"`
+	LicensingTerms                        string  `json:"LicensingTerms" ini:"LicensingTerms"`
+	PrivacyPolicy                         string  `json:"PrivacyPolicy" ini:"PrivacyPolicy"`
+	ProjectID                             GUID    `json:"ProjectID" ini:"ProjectID"`
+	ProjectName                           string  `json:"ProjectName" ini:"ProjectName"`
+	ProjectVersion                        string  `json:"ProjectVersion" ini:"ProjectVersion"`
+	SupportContact                        string  `json:"SupportContact" ini:"SupportContact"`
+	ProjectDisplayedTitle                 Text    `json:"ProjectDisplayedTitle" ini:"ProjectDisplayedTitle"`
+	ProjectDebugTitleInfo                 Text    `json:"ProjectDebugTitleInfo" ini:"ProjectDebugTitleInfo"`
+	ShouldWindowPreserveAspectRatio       bool    `json:"bShouldWindowPreserveAspectRatio" ini:"bShouldWindowPreserveAspectRatio"`
+	UseBorderlessWindow                   bool    `json:"bUseBorderlessWindow" ini:"bUseBorderlessWindow"`
+	StartInVR                             bool    `json:"bStartInVR" ini:"bStartInVR"`
+	AllowWindowResize                     bool    `json:"bAllowWindowResize" ini:"bAllowWindowResize"`
+	AllowClose                            bool    `json:"bAllowClose" ini:"bAllowClose"`
+	AllowMaximize                         bool    `json:"bAllowMaximize" ini:"bAllowMaximize"`
+	AllowMinimize                         bool    `json:"bAllowMinimize" ini:"bAllowMinimize"`
+	EyeOffsetForFakeStereoRenderingDevice float64 `json:"EyeOffsetForFakeStereoRenderingDevice" ini:"EyeOffsetForFakeStereoRenderingDevice"`
+	FOVForFakeStereoRenderingDevice       float64 `json:"FOVForFakeStereoRenderingDevice" ini:"FOVForFakeStereoRenderingDevice"`
+}
+
+// Find returns the ini file in configDir holding GeneralProjectSettings, DefaultGame.ini if none has the section yet
+func Find(configDir string) (string, error) {
+	for _, key := range []string{ProjectIDKey, "ProjectName", "ProjectVersion"} {
+		path, err := ueini.FindKey(configDir, ueini.GeneralProjectSettings, key)
+		if err != nil || path != "" {
+			return path, err
+		}
+	}
+	return filepath.Join(configDir, DefaultGameIni), nil
+}
+
+// Load reads the GeneralProjectSettings from an ini file
+func Load(path string) (*GeneralProjectSettings, error) {
+	answer := &GeneralProjectSettings{}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return answer, nil
+	}
+	cfg, err := ueini.Load(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s", path)
+	}
+	section := cfg.Section(ueini.GeneralProjectSettings)
+	for _, field := range Fields() {
+		if !section.HasKey(field.Name) {
+			continue
+		}
+		if err := field.set(answer, section.Key(field.Name).String()); err != nil {
+			return nil, errors.Wrapf(err, "reading %s from %s", field.Name, path)
+		}
+	}
+	return answer, nil
+}
+
+// ValidateProjectID checks the ID is a GUID written as 32 hex digits, the format unreal saves it in
+func ValidateProjectID(id GUID) error {
+	if id == "" {
+		return errors.New("the project has no ProjectID")
+	}
+	if !projectIDRegex.MatchString(string(id)) {
+		return errors.Errorf("invalid ProjectID %q, expected 32 hex digits such as 0C9A1B2C4D5E6F708192A3B4C5D6E7F8", id)
+	}
+	if strings.Trim(string(id), "0") == "" {
+		return errors.New("invalid ProjectID, it is all zeros")
+	}
+	return nil
+}
+
+// NewProjectID returns a new random ProjectID
+func NewProjectID() (GUID, error) {
+	id, err := utils.NewGUID()
+	return GUID(id), err
+}
@@ -0,0 +1,84 @@
+package settings
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestFindAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Find(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, DefaultGameIni), path)
+
+	settings, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &GeneralProjectSettings{}, settings)
+
+	writeFile(t, filepath.Join(dir, "Game.ini"), "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=0C9A1B2C4D5E6F708192A3B4C5D6E7F8\nProjectName=Shooter\nProjectVersion=1.2.3\n")
+	path, err = Find(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "Game.ini"), path)
+
+	settings, err = Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &GeneralProjectSettings{ProjectID: "0C9A1B2C4D5E6F708192A3B4C5D6E7F8", ProjectName: "Shooter", ProjectVersion: "1.2.3"}, settings)
+}
+
+func TestValidateProjectID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      GUID
+		wantErr bool
+	}{
+		{"Valid", "0C9A1B2C4D5E6F708192A3B4C5D6E7F8", false},
+		{"Lower case", "0c9a1b2c4d5e6f708192a3b4c5d6e7f8", false},
+		{"Empty", "", true},
+		{"Hyphens", "0C9A1B2C-4D5E-6F70-8192-A3B4C5D6E7F8", true},
+		{"Short", "0C9A1B2C", true},
+		{"Zero", "00000000000000000000000000000000", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProjectID(tt.id)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+
+	id, err := NewProjectID()
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateProjectID(id))
+}
+
+func TestDuplicateProjectIDs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "Games", "A", "A.uproject"), "{}")
+	writeFile(t, filepath.Join(root, "Games", "A", "Config", "DefaultGame.ini"), "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=0C9A1B2C4D5E6F708192A3B4C5D6E7F8\n")
+	writeFile(t, filepath.Join(root, "Games", "B", "B.uproject"), "{}")
+	writeFile(t, filepath.Join(root, "Games", "B", "Config", "DefaultGame.ini"), "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=0c9a1b2c4d5e6f708192a3b4c5d6e7f8\n")
+	writeFile(t, filepath.Join(root, "Games", "C", "C.uproject"), "{}")
+	writeFile(t, filepath.Join(root, "Games", "C", "Config", "DefaultGame.ini"), "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=11111111111111111111111111111111\n")
+	writeFile(t, filepath.Join(root, "Games", "D", "D.uproject"), "{}")
+	writeFile(t, filepath.Join(root, "Games", "A", "Saved", "Copy", "A.uproject"), "{}")
+
+	projects, err := FindProjectIDs(root)
+	assert.NoError(t, err)
+	assert.Len(t, projects, 4)
+	assert.Equal(t, GUID(""), projects[3].ProjectID)
+
+	assert.Equal(t, map[string][]string{
+		"0C9A1B2C4D5E6F708192A3B4C5D6E7F8": {
+			filepath.Join(root, "Games", "A", "A.uproject"),
+			filepath.Join(root, "Games", "B", "B.uproject"),
+		},
+	}, DuplicateProjectIDs(projects))
+}
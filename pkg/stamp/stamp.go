@@ -0,0 +1,155 @@
+package stamp
+
+import (
+	"bytes"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/gitutil"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/pathfilter"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/settings"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/ueini"
+	"github.com/pkg/errors"
+)
+
+// For Test Mocks
+var now = time.Now
+
+// Data is what templates can use
+type Data struct {
+	Version     string
+	ProjectName string
+	CompanyName string
+	// GitSHA is the abbreviated commit, GitCommit the full one
+	GitSHA    string
+	GitCommit string
+	GitBranch string
+	// Date is the day the version was set as YYYY-MM-DD
+	Date string
+	// BuildNumber is the build number counter, empty unless it is in use
+	BuildNumber string
+	// Prerelease is the prerelease of a semver version such as beta.2, empty for a release
+	Prerelease string
+}
+
+// NewData collects the template data for a project, git values are left empty outside a repository
+func NewData(projectDir string, version string, projectSettings *settings.GeneralProjectSettings) Data {
+	answer := Data{
+		Version:     version,
+		ProjectName: projectSettings.ProjectName,
+		CompanyName: projectSettings.CompanyName,
+		Date:        now().Format("2006-01-02"),
+	}
+	if answer.ProjectName == "" {
+		if path, err := descriptor.FindProject(projectDir); err == nil {
+			answer.ProjectName = descriptor.NameFromPath(path)
+		}
+	}
+	if gitutil.IsRepository(projectDir) {
+		answer.SetGit(projectDir, "HEAD")
+	}
+	return answer
+}
+
+// SetGit fills the git values from rev of the repository in dir, such as a branch of a bare repository
+func (d *Data) SetGit(dir string, rev string) {
+	d.GitSHA, _ = gitutil.Run(dir, "rev-parse", "--short", rev)
+	d.GitCommit, _ = gitutil.Run(dir, "rev-parse", rev)
+	d.GitBranch, _ = gitutil.Run(dir, "rev-parse", "--abbrev-ref", rev)
+}
+
+// Render executes a template, referring to a value that doesn't exist is an error
+func Render(text string, data Data) (string, error) {
+	tmpl, err := template.New("template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing template %q", text)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", errors.Wrapf(err, "rendering template %q", text)
+	}
+	return b.String(), nil
+}
+
+// PlanFiles renders text and plans edit on every file below root matching the pathfilter.Glob pattern,
+// with the rendered value. It is an error if no file matches.
+func PlanFiles(plan *edit.Plan, root string, pattern string, text string, data Data, reason string, edit func(path string, content []byte, value string) ([]byte, error)) error {
+	value, err := Render(text, data)
+	if err != nil {
+		return err
+	}
+	paths, err := pathfilter.Glob(root, pattern)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.Errorf("no file matches %s in %s", pattern, root)
+	}
+	for _, path := range paths {
+		path := path
+		err := plan.Edit(path, reason, func(content []byte) ([]byte, error) {
+			return edit(path, content, value)
+		})
+		if err != nil {
+			return err
+		}
+		log.Logger().Debugf("Rendered %s of %s to %q", reason, path, value)
+	}
+	return nil
+}
+
+// Plan renders every template and adds the ini edits to the plan. Files are relative to configDir and
+// default to defaultFile, the file the version is written to. Values that may only increase, such as the
+// Android StoreVersion, are checked by the guard.
+func Plan(plan *edit.Plan, configDir string, defaultFile string, templates []config.Template, data Data, g *guard.Guard) error {
+	for _, t := range templates {
+		value, err := Render(t.Template, data)
+		if err != nil {
+			return errors.Wrapf(err, "template for %s", t.Key)
+		}
+		path := defaultFile
+		if t.File != "" {
+			path = filepath.Join(configDir, t.File)
+		}
+		section := t.Section
+		if section == "" {
+			section = ueini.GeneralProjectSettings
+		}
+		if err := setKey(plan, path, section, t.Key, value, g); err != nil {
+			return err
+		}
+		log.Logger().Debugf("Rendered %s to %q", t.Key, value)
+	}
+	return nil
+}
+
+// setKey writes the value, encoding it for GeneralProjectSettings fields so text fields keep their
+// localization key
+func setKey(plan *edit.Plan, path string, section string, key string, value string, g *guard.Guard) error {
+	return plan.Edit(path, key, func(content []byte) ([]byte, error) {
+		cfg, err := ueini.Load(content)
+		if err != nil {
+			return nil, err
+		}
+		name := key
+		if section == ueini.GeneralProjectSettings {
+			if field, err := settings.LookupField(key); err == nil {
+				if value, err = field.Encode(value, cfg.Section(section).Key(field.Name).String()); err != nil {
+					return nil, err
+				}
+				name = field.Name
+			}
+		}
+		if err := g.IniKey(path, section, name, cfg.Section(section).Key(name).String(), value); err != nil {
+			return nil, err
+		}
+		cfg.Section(section).Key(name).SetValue(value)
+		return ueini.Bytes(cfg)
+	})
+}
@@ -0,0 +1,107 @@
+package stamp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/guard"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	data := Data{Version: "1.2.3", ProjectName: "Shooter", GitSHA: "abc1234"}
+	got, err := Render("{{.ProjectName}} {{.Version}} ({{.GitSHA}})", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Shooter 1.2.3 (abc1234)", got)
+
+	_, err = Render("{{.Missing}}", data)
+	assert.Error(t, err)
+	_, err = Render("{{.Version", data)
+	assert.Error(t, err)
+}
+
+func TestNewData(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Shooter.uproject"), []byte("{}"), 0644))
+
+	data := NewData(dir, "1.2.3", &settings.GeneralProjectSettings{CompanyName: "Studio"})
+	assert.Equal(t, Data{Version: "1.2.3", ProjectName: "Shooter", CompanyName: "Studio", Date: "2026-03-04"}, data)
+}
+
+func TestPlan(t *testing.T) {
+	configDir := t.TempDir()
+	game := filepath.Join(configDir, "DefaultGame.ini")
+	assert.NoError(t, ioutil.WriteFile(game, []byte(`[/Script/EngineSettings.GeneralProjectSettings]
+ProjectVersion=1.2.3
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "ABC", "Shooter")
+`), 0644))
+
+	plan := edit.NewPlan()
+	templates := []config.Template{
+		{Key: "ProjectDisplayedTitle", Template: "{{.ProjectName}} {{.Version}}"},
+		{Key: "ProjectDebugTitleInfo", Template: "{{.GitSHA}}"},
+		{File: "DefaultEngine.ini", Section: "/Script/Engine.Engine", Key: "BuildLabel", Template: "v{{.Version}}"},
+	}
+	data := Data{Version: "1.2.3", ProjectName: "Shooter", GitSHA: "abc1234"}
+	assert.NoError(t, Plan(plan, configDir, game, templates, data, &guard.Guard{}))
+	assert.NoError(t, plan.Apply())
+
+	content, err := ioutil.ReadFile(game)
+	assert.NoError(t, err)
+	assert.Equal(t, `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectVersion=1.2.3
+ProjectDisplayedTitle=NSLOCTEXT("[/Script/EngineSettings]", "ABC", "Shooter 1.2.3")
+ProjectDebugTitleInfo=INVTEXT("abc1234")
+`, string(content))
+
+	content, err = ioutil.ReadFile(filepath.Join(configDir, "DefaultEngine.ini"))
+	assert.NoError(t, err)
+	assert.Equal(t, "[/Script/Engine.Engine]\nBuildLabel=v1.2.3\n", string(content))
+}
+
+func TestPlanStoreVersion(t *testing.T) {
+	configDir := t.TempDir()
+	engine := filepath.Join(configDir, "DefaultEngine.ini")
+	assert.NoError(t, ioutil.WriteFile(engine, []byte("[/Script/AndroidRuntimeSettings.AndroidRuntimeSettings]\nStoreVersion=10\n"), 0644))
+	templates := []config.Template{
+		{File: "DefaultEngine.ini", Section: "/Script/AndroidRuntimeSettings.AndroidRuntimeSettings", Key: "StoreVersion", Template: "{{.Version}}"},
+	}
+
+	err := Plan(edit.NewPlan(), configDir, engine, templates, Data{Version: "9"}, &guard.Guard{})
+	assert.True(t, guard.IsDowngrade(err))
+	assert.NoError(t, Plan(edit.NewPlan(), configDir, engine, templates, Data{Version: "9"}, &guard.Guard{AllowDowngrade: true}))
+	assert.NoError(t, Plan(edit.NewPlan(), configDir, engine, templates, Data{Version: "11"}, &guard.Guard{}))
+}
+
+func TestPlanFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"Docs/a.md", "Docs/Nested/b.md", "Docs/c.txt"} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(root, path), []byte("version\n"), 0644))
+	}
+	plan := edit.NewPlan()
+	var paths []string
+	err := PlanFiles(plan, root, "Docs/*.md", "v{{.Version}}", Data{Version: "1.2.3"}, "docs", func(path string, content []byte, value string) ([]byte, error) {
+		paths = append(paths, path)
+		return []byte(value + "\n"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "Docs", "Nested", "b.md"), filepath.Join(root, "Docs", "a.md")}, paths)
+	assert.NoError(t, plan.Apply())
+	got, err := ioutil.ReadFile(filepath.Join(root, "Docs", "a.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.3\n", string(got))
+
+	noop := func(path string, content []byte, value string) ([]byte, error) { return content, nil }
+	assert.EqualError(t, PlanFiles(edit.NewPlan(), root, "Missing/*.md", "x", Data{}, "docs", noop), "no file matches Missing/*.md in "+root)
+	assert.Error(t, PlanFiles(edit.NewPlan(), root, "Docs/*.md", "{{.Missing}}", Data{}, "docs", noop))
+}
@@ -0,0 +1,71 @@
+package steam
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/common/log"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/vdf"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DescKey is the build description shown on the builds page of Steamworks
+	DescKey = "AppBuild.Desc"
+	// SetLiveKey is the branch the build is set live on once it is uploaded
+	SetLiveKey = "AppBuild.SetLive"
+
+	// DefaultDesc is the description template used when the config has none
+	DefaultDesc = "{{.ProjectName}} {{.Version}}"
+)
+
+// Branch returns the branch a version with the given prerelease is set live on. The prerelease is
+// reduced to its leading letters, beta.2 and beta2 are both beta, which is looked up in the configured
+// branches and else used as the branch name. A release goes to the release branch.
+func Branch(cfg config.Steam, prerelease string) string {
+	if prerelease == "" {
+		return cfg.ReleaseBranch
+	}
+	name := strings.ToLower(strings.SplitN(prerelease, ".", 2)[0])
+	if end := strings.IndexFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }); end > 0 {
+		name = name[:end]
+	}
+	for key, branch := range cfg.Branches {
+		if strings.EqualFold(key, name) {
+			return branch
+		}
+	}
+	return name
+}
+
+// Plan writes the description and the SetLive branch to every app build file matching the config. Files
+// are relative to root, the folder holding .uvu. An empty branch clears SetLive, or leaves it out when
+// the file has none, so a release isn't set live on the branch of the last prerelease.
+func Plan(plan *edit.Plan, root string, cfg config.Steam, data stamp.Data) error {
+	if cfg.Files == "" {
+		return nil
+	}
+	text := cfg.Desc
+	if text == "" {
+		text = DefaultDesc
+	}
+	branch := Branch(cfg, data.Prerelease)
+	err := stamp.PlanFiles(plan, root, cfg.Files, text, data, "steam", func(path string, content []byte, desc string) ([]byte, error) {
+		content, err := vdf.Set(content, DescKey, desc)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := vdf.GetString(content, SetLiveKey); vdf.IsNotFound(err) && branch == "" {
+			return content, nil
+		}
+		return vdf.Set(content, SetLiveKey, branch)
+	})
+	if err != nil {
+		return errors.Wrap(err, "steam")
+	}
+	log.Logger().Debugf("Steam builds are set live on %q", branch)
+	return nil
+}
@@ -0,0 +1,94 @@
+package steam
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranch(t *testing.T) {
+	cfg := config.Steam{Branches: map[string]string{"rc": "staging", "Alpha": "internal"}, ReleaseBranch: "public"}
+	tests := []struct {
+		name       string
+		cfg        config.Steam
+		prerelease string
+		want       string
+	}{
+		{"Release", cfg, "", "public"},
+		{"Release without a branch", config.Steam{}, "", ""},
+		{"Mapped", cfg, "rc.1", "staging"},
+		{"Mapped ignoring case", cfg, "alpha", "internal"},
+		{"Number suffix", cfg, "rc2", "staging"},
+		{"Not mapped", cfg, "beta.3", "beta"},
+		{"Numeric", config.Steam{}, "7", "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Branch(tt.cfg, tt.prerelease))
+		})
+	}
+}
+
+const appBuild = `"AppBuild"
+{
+	"AppID"	"1000" // your AppID
+	"Desc"	"" // internal description for this build
+	"ContentRoot"	"..\content\"
+	"Depots"
+	{
+		"1001"	"depot_build_1001.vdf"
+	}
+}
+`
+
+func TestPlan(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "Steam")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	win := filepath.Join(dir, "app_build_1000.vdf")
+	assert.NoError(t, ioutil.WriteFile(win, []byte(appBuild), 0644))
+	cfg := config.Steam{Files: "Steam/app_build_*.vdf", Desc: "{{.ProjectName}} {{.Version}} ({{.GitSHA}})"}
+
+	plan := edit.NewPlan()
+	assert.NoError(t, Plan(plan, root, cfg, stamp.Data{ProjectName: "Shooter", Version: "1.2.0-beta.1", GitSHA: "abc1234", Prerelease: "beta.1"}))
+	assert.NoError(t, plan.Apply())
+	got, err := ioutil.ReadFile(win)
+	assert.NoError(t, err)
+	assert.Equal(t, `"AppBuild"
+{
+	"AppID"	"1000" // your AppID
+	"Desc"	"Shooter 1.2.0-beta.1 (abc1234)" // internal description for this build
+	"ContentRoot"	"..\content\"
+	"Depots"
+	{
+		"1001"	"depot_build_1001.vdf"
+	}
+	"SetLive"	"beta"
+}
+`, string(got))
+
+	// a release clears the branch of the prerelease
+	plan = edit.NewPlan()
+	assert.NoError(t, Plan(plan, root, cfg, stamp.Data{ProjectName: "Shooter", Version: "1.2.0", GitSHA: "def5678"}))
+	assert.NoError(t, plan.Apply())
+	got, err = ioutil.ReadFile(win)
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "\"Desc\"\t\"Shooter 1.2.0 (def5678)\" //")
+	assert.Contains(t, string(got), "\"SetLive\"\t\"\"\n")
+
+	// a release without SetLive doesn't get one
+	assert.NoError(t, ioutil.WriteFile(win, []byte(appBuild), 0644))
+	plan = edit.NewPlan()
+	assert.NoError(t, Plan(plan, root, cfg, stamp.Data{ProjectName: "Shooter", Version: "1.2.0"}))
+	assert.NotContains(t, string(plan.Changes()[0].After), "SetLive")
+
+	assert.NoError(t, Plan(edit.NewPlan(), root, config.Steam{}, stamp.Data{}))
+	assert.Error(t, Plan(edit.NewPlan(), root, config.Steam{Files: "Missing/*.vdf"}, stamp.Data{}))
+	assert.Error(t, Plan(edit.NewPlan(), root, config.Steam{Files: cfg.Files, Desc: "{{.Missing}}"}, stamp.Data{}))
+}
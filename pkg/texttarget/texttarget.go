@@ -0,0 +1,90 @@
+package texttarget
+
+import (
+	"regexp"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// VersionGroup is the named group of a pattern whose text is replaced
+	VersionGroup = "version"
+
+	// DefaultReplace is the template used when a target has none
+	DefaultReplace = "{{.Version}}"
+)
+
+// Target is a text target with its pattern compiled
+type Target struct {
+	config.TextTarget
+	pattern *regexp.Regexp
+	group   int
+}
+
+// Compile checks the pattern has a version group
+func Compile(target config.TextTarget) (*Target, error) {
+	pattern, err := regexp.Compile(target.Pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing pattern %q", target.Pattern)
+	}
+	group := -1
+	for i, name := range pattern.SubexpNames() {
+		if name == VersionGroup {
+			group = i
+		}
+	}
+	if group < 0 {
+		return nil, errors.Errorf("pattern %q has no (?P<%s>...) group", target.Pattern, VersionGroup)
+	}
+	if target.Replace == "" {
+		target.Replace = DefaultReplace
+	}
+	return &Target{TextTarget: target, pattern: pattern, group: group}, nil
+}
+
+// Substitute writes value in place of the version group of every match, returning the number of matches
+func (t *Target) Substitute(content []byte, value string) ([]byte, int) {
+	matches := t.pattern.FindAllSubmatchIndex(content, -1)
+	var answer []byte
+	last, count := 0, 0
+	for _, match := range matches {
+		start, end := match[2*t.group], match[2*t.group+1]
+		if start < 0 {
+			continue
+		}
+		answer = append(answer, content[last:start]...)
+		answer = append(answer, value...)
+		last = end
+		count++
+	}
+	if count == 0 {
+		return content, 0
+	}
+	return append(answer, content[last:]...), count
+}
+
+// Plan renders every target and adds the edits of the files they match to the plan. Files are relative
+// to root, the folder holding .uvu. A target that matches no file, or a file its pattern doesn't match,
+// is an error so a moved file or a changed format doesn't silently stop being updated.
+func Plan(plan *edit.Plan, root string, targets []config.TextTarget, data stamp.Data) error {
+	for i, t := range targets {
+		target, err := Compile(t)
+		if err != nil {
+			return errors.Wrapf(err, "text target %d", i+1)
+		}
+		err = stamp.PlanFiles(plan, root, target.Files, target.Replace, data, "text target "+target.Files, func(path string, content []byte, value string) ([]byte, error) {
+			after, n := target.Substitute(content, value)
+			if n == 0 {
+				return nil, errors.Errorf("pattern %q matches nothing", target.Pattern)
+			}
+			return after, nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "text target %d", i+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package texttarget
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/config"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/stamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		content string
+		value   string
+		want    string
+		count   int
+	}{
+		{"Badge", `version-(?P<version>[0-9.]+)-blue`, "![v](https://img.shields.io/badge/version-1.0.0-blue)\n", "1.2.0", "![v](https://img.shields.io/badge/version-1.2.0-blue)\n", 1},
+		{"Every match", `AppVersion (?P<version>\S+)`, "AppVersion 1.0\nAppVersion 1.0\n", "2.0", "AppVersion 2.0\nAppVersion 2.0\n", 2},
+		{"Optional group", `v(?P<version>\d+)?x`, "vx v1x", "9", "vx v9x", 1},
+		{"No match", `"version": "(?P<version>[^"]+)"`, "{}\n", "1.2.0", "{}\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := Compile(config.TextTarget{Files: "*", Pattern: tt.pattern})
+			assert.NoError(t, err)
+			got, count := target.Substitute([]byte(tt.content), tt.value)
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.count, count)
+		})
+	}
+
+	_, err := Compile(config.TextTarget{Files: "*", Pattern: `v([0-9.]+)`})
+	assert.Error(t, err)
+	_, err = Compile(config.TextTarget{Files: "*", Pattern: `v(?P<version>`})
+	assert.Error(t, err)
+}
+
+func TestPlan(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"README.md":                 "![v](https://img.shields.io/badge/version-1.0.0-blue)\n",
+		"Installer/Setup.iss":       "#define AppVersion \"1.0.0\"\n",
+		"Installer/Extra/Demo.iss":  "#define AppVersion \"1.0.0\"\n",
+		"Installer/notes.txt":       "AppVersion \"1.0.0\"\n",
+		".git/Installer/Setup.iss":  "#define AppVersion \"1.0.0\"\n",
+		"Web/package.json":          "{\n  \"version\": \"1.0.0\"\n}\n",
+		"Web/node_modules/x/a.json": "{}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	targets := []config.TextTarget{
+		{Files: "README.md", Pattern: `version-(?P<version>[0-9.]+)-blue`},
+		{Files: "Installer/*.iss", Pattern: `AppVersion "(?P<version>[^"]+)"`, Replace: "{{.Version}}.{{.BuildNumber}}"},
+		{Files: "./Web/package.json", Pattern: `"version": "(?P<version>[^"]+)"`},
+	}
+	data := stamp.Data{Version: "1.2.0", BuildNumber: "7"}
+
+	plan := edit.NewPlan()
+	assert.NoError(t, Plan(plan, root, targets, data))
+	var changed []string
+	for _, change := range plan.Changes() {
+		rel, _ := filepath.Rel(root, change.Path)
+		changed = append(changed, filepath.ToSlash(rel))
+	}
+	assert.Equal(t, []string{"Installer/Extra/Demo.iss", "Installer/Setup.iss", "README.md", "Web/package.json"}, changed)
+	assert.NoError(t, plan.Apply())
+
+	got, err := ioutil.ReadFile(filepath.Join(root, "Installer", "Setup.iss"))
+	assert.NoError(t, err)
+	assert.Equal(t, "#define AppVersion \"1.2.0.7\"\n", string(got))
+	got, err = ioutil.ReadFile(filepath.Join(root, "Web", "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"version\": \"1.2.0\"\n}\n", string(got))
+
+	for _, bad := range []config.TextTarget{
+		{Files: "Missing/*.iss", Pattern: `AppVersion "(?P<version>[^"]+)"`},
+		{Files: "Web/node_modules/x/a.json", Pattern: `"version": "(?P<version>[^"]+)"`},
+		{Files: "README.md", Pattern: `version-(?P<version>[0-9.]+)-blue`, Replace: "{{.Missing}}"},
+	} {
+		assert.Error(t, Plan(edit.NewPlan(), root, []config.TextTarget{bad}, data), bad.Files)
+	}
+}
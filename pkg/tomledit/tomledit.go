@@ -0,0 +1,510 @@
+package tomledit
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Kind is the type of a TOML value
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindLiteralString
+	KindMultilineString
+	KindInteger
+	KindFloat
+	KindBool
+	KindDateTime
+	KindArray
+	KindInlineTable
+)
+
+// Value is a `key = value` of a TOML document which remembers where its value lives in the original
+// document so it can be replaced without reformatting anything around it
+type Value struct {
+	// Path is the table and the key, an array of tables adds an index such as [bin [0] name]
+	Path  []string
+	Kind  Kind
+	Start int
+	End   int
+}
+
+// Raw returns the original text of the value
+func (v *Value) Raw(data []byte) []byte {
+	return data[v.Start:v.End]
+}
+
+// Parse returns every key of the document with a value, keys inside inline tables are not included
+func Parse(data []byte) ([]*Value, error) {
+	p := &parser{data: data, arrays: map[string]int{}}
+	var answer []*Value
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return answer, nil
+		}
+		if p.data[p.pos] == '[' {
+			if err := p.header(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		keys, err := p.keys('=')
+		if err != nil {
+			return nil, err
+		}
+		p.pos++
+		p.skipSpace()
+		start := p.pos
+		kind, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		path := append(append([]string{}, p.table...), keys...)
+		answer = append(answer, &Value{Path: path, Kind: kind, Start: start, End: p.pos})
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Lookup finds the value at a path such as `version`, `build.version` or `bin[0].version`
+func Lookup(data []byte, path string) (*Value, error) {
+	want, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		if strings.Join(value.Path, "\x00") == strings.Join(want, "\x00") {
+			return value, nil
+		}
+	}
+	return nil, &NotFoundError{Path: path}
+}
+
+// NotFoundError is returned when a path does not exist in a document
+type NotFoundError struct {
+	Path string
+}
+
+func (e *NotFoundError) Error() string {
+	return "no value found for path " + e.Path
+}
+
+// IsNotFound returns true if the error is a NotFoundError
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*NotFoundError)
+	return ok
+}
+
+// GetString returns the value at the given path, strings are unquoted and anything else is returned as
+// written
+func GetString(data []byte, path string) (string, error) {
+	value, err := Lookup(data, path)
+	if err != nil {
+		return "", err
+	}
+	raw := string(value.Raw(data))
+	switch value.Kind {
+	case KindString:
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return "", errors.Wrapf(err, "decoding %s", path)
+		}
+		return s, nil
+	case KindLiteralString:
+		return raw[1 : len(raw)-1], nil
+	case KindMultilineString:
+		return strings.TrimPrefix(raw[3:len(raw)-3], "\n"), nil
+	}
+	return raw, nil
+}
+
+var (
+	integerValue = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	dateValue    = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	floatValue   = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+)
+
+// Set replaces the value at the given path, keeping its type: strings keep their quoting style where the
+// value allows it, numbers and bools are only replaced by a value of the same type. Everything else in the
+// document is left untouched. The key must already exist.
+func Set(data []byte, path string, value string) ([]byte, error) {
+	current, err := Lookup(data, path)
+	if err != nil {
+		return nil, err
+	}
+	var encoded string
+	switch current.Kind {
+	case KindLiteralString:
+		encoded = "'" + value + "'"
+		if strings.ContainsAny(value, "'\r\n") {
+			encoded = quote(value)
+		}
+	case KindString, KindMultilineString:
+		encoded = quote(value)
+	case KindInteger:
+		if !integerValue.MatchString(value) {
+			return nil, errors.Errorf("%s is an integer, %q is not", path, value)
+		}
+		encoded = value
+	case KindFloat:
+		if !floatValue.MatchString(value) {
+			return nil, errors.Errorf("%s is a float, %q is not", path, value)
+		}
+		encoded = value
+	case KindBool:
+		if value != "true" && value != "false" {
+			return nil, errors.Errorf("%s is a bool, %q is not", path, value)
+		}
+		encoded = value
+	default:
+		return nil, errors.Errorf("%s is not a string, number or bool", path)
+	}
+	answer := make([]byte, 0, len(data)+len(encoded))
+	answer = append(answer, data[:current.Start]...)
+	answer = append(answer, encoded...)
+	return append(answer, data[current.End:]...), nil
+}
+
+// quote writes a basic string, escaping what TOML requires
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// splitPath splits a path expression into keys and `[n]` array indexes, the same syntax as jsonedit
+func splitPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil, errors.Errorf("invalid path %q: no key", path)
+	}
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, errors.Errorf("invalid path %q: empty key", path)
+		}
+		for part != "" {
+			open := strings.Index(part, "[")
+			if open < 0 {
+				segments = append(segments, part)
+				break
+			}
+			if open > 0 {
+				segments = append(segments, part[:open])
+			}
+			end := strings.Index(part, "]")
+			if end < open {
+				return nil, errors.Errorf("invalid path %q: unterminated index", path)
+			}
+			if _, err := strconv.Atoi(part[open+1 : end]); err != nil {
+				return nil, errors.Errorf("invalid path %q: bad index %s", path, part[open:end+1])
+			}
+			segments = append(segments, part[open:end+1])
+			part = part[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+type parser struct {
+	data []byte
+	pos  int
+	// table is the path of the current table
+	table []string
+	// arrays counts the elements of each array of tables seen so far
+	arrays map[string]int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(string(p.data[:p.pos]), "\n") + 1
+	return errors.Errorf("invalid TOML on line %d: %s", line, errors.Errorf(format, args...))
+}
+
+// skipSpace skips spaces and tabs
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments
+func (p *parser) skipBlank() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipComment() {
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		p.pos++
+	}
+}
+
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == '#' {
+		p.skipComment()
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		return p.errorf("expected the end of the line")
+	}
+	return nil
+}
+
+// header parses [table] or [[array of tables]]
+func (p *parser) header() error {
+	array := strings.HasPrefix(string(p.data[p.pos:]), "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	keys, err := p.keys(']')
+	if err != nil {
+		return err
+	}
+	if array {
+		if !strings.HasPrefix(string(p.data[p.pos:]), "]]") {
+			return p.errorf("expected ]]")
+		}
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	p.table = p.indexed(keys, array)
+	return p.endOfLine()
+}
+
+// indexed adds the index of the current element after every array of tables the keys pass through, a new
+// element is started when array is true
+func (p *parser) indexed(keys []string, array bool) []string {
+	var answer []string
+	for i, key := range keys {
+		answer = append(answer, key)
+		name := strings.Join(answer, "\x00")
+		if array && i == len(keys)-1 {
+			p.arrays[name]++
+		}
+		if n, ok := p.arrays[name]; ok {
+			answer = append(answer, "["+strconv.Itoa(n-1)+"]")
+		}
+	}
+	return answer
+}
+
+// keys parses a dotted key up to the terminator, which is left for the caller
+func (p *parser) keys(terminator byte) ([]string, error) {
+	var answer []string
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unexpected end of input in a key")
+		}
+		switch c := p.data[p.pos]; {
+		case c == '"':
+			start := p.pos
+			if err := p.basicString(); err != nil {
+				return nil, err
+			}
+			var key string
+			if err := json.Unmarshal(p.data[start:p.pos], &key); err != nil {
+				return nil, p.errorf("bad key %s", p.data[start:p.pos])
+			}
+			answer = append(answer, key)
+		case c == '\'':
+			start := p.pos
+			if err := p.literalString(); err != nil {
+				return nil, err
+			}
+			answer = append(answer, string(p.data[start+1:p.pos-1]))
+		default:
+			start := p.pos
+			for p.pos < len(p.data) && isBareKey(p.data[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("unexpected character %q in a key", c)
+			}
+			answer = append(answer, string(p.data[start:p.pos]))
+		}
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unexpected end of input in a key")
+		}
+		switch p.data[p.pos] {
+		case '.':
+			p.pos++
+		case terminator:
+			return answer, nil
+		default:
+			return nil, p.errorf("expected %q after key %s", terminator, strings.Join(answer, "."))
+		}
+	}
+}
+
+func isBareKey(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *parser) value() (Kind, error) {
+	if p.pos >= len(p.data) {
+		return 0, p.errorf("unexpected end of input")
+	}
+	rest := string(p.data[p.pos:])
+	switch {
+	case strings.HasPrefix(rest, `"""`), strings.HasPrefix(rest, "'''"):
+		return KindMultilineString, p.multilineString(rest[:3])
+	case rest[0] == '"':
+		return KindString, p.basicString()
+	case rest[0] == '\'':
+		return KindLiteralString, p.literalString()
+	case rest[0] == '[':
+		return KindArray, p.container('[', ']')
+	case rest[0] == '{':
+		return KindInlineTable, p.container('{', '}')
+	}
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte(" \t\r\n#,]}", p.data[p.pos]) < 0 {
+		p.pos++
+	}
+	// dates may have a space between the date and the time
+	if p.pos+1 < len(p.data) && p.data[p.pos] == ' ' && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' &&
+		dateValue.Match(p.data[start:p.pos]) {
+		p.pos++
+		for p.pos < len(p.data) && strings.IndexByte(" \t\r\n#,]}", p.data[p.pos]) < 0 {
+			p.pos++
+		}
+	}
+	token := string(p.data[start:p.pos])
+	switch {
+	case token == "":
+		return 0, p.errorf("missing value")
+	case token == "true" || token == "false":
+		return KindBool, nil
+	case integerValue.MatchString(token) || strings.HasPrefix(token, "0x") || strings.HasPrefix(token, "0o") || strings.HasPrefix(token, "0b"):
+		return KindInteger, nil
+	case floatValue.MatchString(token) || strings.TrimLeft(token, "+-") == "inf" || strings.TrimLeft(token, "+-") == "nan":
+		return KindFloat, nil
+	case token[0] >= '0' && token[0] <= '9':
+		return KindDateTime, nil
+	}
+	return 0, p.errorf("unexpected value %q", token)
+}
+
+func (p *parser) basicString() error {
+	p.pos++
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			return nil
+		case '\n':
+			return p.errorf("unterminated string")
+		default:
+			p.pos++
+		}
+	}
+	return p.errorf("unterminated string")
+}
+
+func (p *parser) literalString() error {
+	end := strings.IndexAny(string(p.data[p.pos+1:]), "'\n")
+	if end < 0 || p.data[p.pos+1+end] != '\'' {
+		return p.errorf("unterminated string")
+	}
+	p.pos += end + 2
+	return nil
+}
+
+func (p *parser) multilineString(delimiter string) error {
+	p.pos += 3
+	for p.pos < len(p.data) {
+		if delimiter == `"""` && p.data[p.pos] == '\\' {
+			p.pos += 2
+			continue
+		}
+		if strings.HasPrefix(string(p.data[p.pos:]), delimiter) {
+			p.pos += 3
+			// up to two quotes may directly precede the closing delimiter
+			for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] == delimiter[0]; i++ {
+				p.pos++
+			}
+			return nil
+		}
+		p.pos++
+	}
+	return p.errorf("unterminated string")
+}
+
+// container skips an array or inline table, including what is nested inside it
+func (p *parser) container(open byte, close byte) error {
+	p.pos++
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return p.errorf("unterminated %c", open)
+		}
+		switch p.data[p.pos] {
+		case close:
+			p.pos++
+			return nil
+		case ',':
+			p.pos++
+			continue
+		}
+		if open == '{' {
+			if _, err := p.keys('='); err != nil {
+				return err
+			}
+			p.pos++
+			p.skipSpace()
+		}
+		if _, err := p.value(); err != nil {
+			return err
+		}
+	}
+}
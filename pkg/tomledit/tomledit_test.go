@@ -0,0 +1,118 @@
+package tomledit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const manifest = `# build manifest
+version = "1.0.0" # the game version
+name = 'Shooter'
+notes = """
+First line
+"""
+released = 2024-05-17 10:00:00
+platforms = [
+  "Win64", # main
+  "Linux",
+]
+
+[build]
+number = 41
+ratio = 0.5
+shipping = true
+"label.name" = "nightly"
+info = { branch = "main", version = "0.0.0" }
+
+[[bin]]
+name = "game"
+version = "1.0.0"
+
+[[bin]]
+name = "server"
+version = "1.0.0"
+
+[bin.meta]
+owner = "ops"
+`
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		value   string
+		before  string
+		after   string
+		wantErr bool
+	}{
+		{"Top level", "version", "1.2.0", `version = "1.0.0" # the game version`, `version = "1.2.0" # the game version`, false},
+		{"JSON style path", "$.version", "1.2.0", `version = "1.0.0" #`, `version = "1.2.0" #`, false},
+		{"Literal string", "name", "Shooter 2", `name = 'Shooter'`, `name = 'Shooter 2'`, false},
+		{"Literal string needing escapes", "name", "It's", `name = 'Shooter'`, `name = "It's"`, false},
+		{"Table", "build.number", "42", "number = 41\n", "number = 42\n", false},
+		{"Float", "build.ratio", "1.5", "ratio = 0.5", "ratio = 1.5", false},
+		{"Bool", "build.shipping", "false", "shipping = true", "shipping = false", false},
+		{"Key with a dot", `build.label.name`, "beta", `"label.name" = "nightly"`, `"label.name" = "beta"`, true},
+		{"Array of tables", "bin[1].version", "2.0.0", "name = \"server\"\nversion = \"1.0.0\"", "name = \"server\"\nversion = \"2.0.0\"", false},
+		{"Sub-table of an array of tables", "bin[1].meta.owner", "dev", `owner = "ops"`, `owner = "dev"`, false},
+		{"Escaped", "version", `say "hi"`, `version = "1.0.0"`, `version = "say \"hi\""`, false},
+		{"Integer mismatch", "build.number", "1.2.0", "", "", true},
+		{"Bool mismatch", "build.shipping", "yes", "", "", true},
+		{"Array", "platforms", "Win64", "", "", true},
+		{"Inline table key", "build.info.version", "1.2.0", "", "", true},
+		{"Missing", "build.version", "1.2.0", "", "", true},
+		{"Bad path", "bin[x].version", "1.2.0", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Set([]byte(manifest), tt.path, tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			if err == nil {
+				assert.Equal(t, strings.Replace(manifest, tt.before, tt.after, 1), string(got))
+			}
+		})
+	}
+}
+
+func TestGetString(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"version", "1.0.0"},
+		{"name", "Shooter"},
+		{"notes", "First line\n"},
+		{"released", "2024-05-17 10:00:00"},
+		{"build.number", "41"},
+		{`build."label.name"`, ""},
+		{"bin[0].name", "game"},
+		{"bin[1].name", "server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := GetString([]byte(manifest), tt.path)
+			if tt.want == "" {
+				assert.True(t, IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"version = \"1.0.0\n",
+		"version = \n",
+		"version \"1.0.0\"\n",
+		"[build\nnumber = 1\n",
+		"platforms = [1, 2\n",
+		"version = \"1.0.0\" extra\n",
+	} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
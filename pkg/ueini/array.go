@@ -0,0 +1,314 @@
+package ueini
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/ini.v1"
+)
+
+// Operator is the prefix unreal puts in front of a key to edit an array built up by the config files
+// loaded before this one
+type Operator string
+
+const (
+	// OpSet replaces the value
+	OpSet Operator = ""
+	// OpAdd adds the value unless the array already has it
+	OpAdd Operator = "+"
+	// OpAddDuplicate adds the value even if the array already has it
+	OpAddDuplicate Operator = "."
+	// OpRemove removes the value from the array
+	OpRemove Operator = "-"
+	// OpClear empties the array
+	OpClear Operator = "!"
+
+	// ClearArrayValue is the value unreal writes after the clear operator
+	ClearArrayValue = "ClearArray"
+)
+
+// entry is one Key=Value line of a section
+type entry struct {
+	key   string
+	value string
+	// line is the index of the line in the file, -1 for a line that is added
+	line int
+}
+
+func newEntry(key string, value string) entry {
+	return entry{key: key, value: value, line: -1}
+}
+
+// SplitKey returns the array operator of a key and its name without it
+func SplitKey(key string) (Operator, string) {
+	if key == "" {
+		return OpSet, key
+	}
+	switch op := Operator(key[:1]); op {
+	case OpAdd, OpAddDuplicate, OpRemove, OpClear:
+		return op, key[1:]
+	}
+	return OpSet, key
+}
+
+// Get returns every value of the key, the key includes its operator so `+Paths` returns the values
+// added with `+Paths=`
+func Get(section *ini.Section, key string) []string {
+	if !section.HasKey(key) {
+		return nil
+	}
+	return section.Key(key).ValueWithShadows()
+}
+
+// set applies the operator of key to the lines of a section: a plain key is replaced by the values, `+`
+// and `.` add the values, `-` removes them and `!` clears the array before adding the values with `+`
+func set(entries []entry, key string, values []string) ([]entry, error) {
+	op, name := SplitKey(key)
+	if name == "" {
+		return nil, errors.Errorf("invalid key %q", key)
+	}
+	if op != OpClear && len(values) == 0 {
+		return nil, errors.Errorf("no value given for %s", key)
+	}
+	switch op {
+	case OpSet:
+		// the first value takes the line of the current one so it is changed in place
+		at, line := indexOf(entries, name), -1
+		if at >= 0 {
+			line = entries[at].line
+		}
+		entries = without(entries, func(e entry) bool { return e.key == name })
+		var added []entry
+		for _, value := range values {
+			added = append(added, entry{key: name, value: value, line: line})
+			line = -1
+		}
+		if at < 0 {
+			at = len(entries)
+		}
+		entries = append(entries[:at], append(added, entries[at:]...)...)
+	case OpAdd:
+		for _, value := range values {
+			entries = without(entries, matching(value, string(OpRemove)+name))
+			if !has(entries, value, name, string(OpAdd)+name, string(OpAddDuplicate)+name) {
+				entries = insertAfter(entries, name, newEntry(key, value))
+			}
+		}
+	case OpAddDuplicate:
+		for _, value := range values {
+			entries = insertAfter(entries, name, newEntry(key, value))
+		}
+	case OpRemove:
+		for _, value := range values {
+			entries = without(entries, matching(value, name, string(OpAdd)+name, string(OpAddDuplicate)+name))
+			if !has(entries, value, key) {
+				entries = insertAfter(entries, name, newEntry(key, value))
+			}
+		}
+	case OpClear:
+		entries = without(entries, func(e entry) bool {
+			_, n := SplitKey(e.key)
+			return n == name
+		})
+		entries = append(entries, newEntry(key, ClearArrayValue))
+		for _, value := range values {
+			entries = append(entries, newEntry(string(OpAdd)+name, value))
+		}
+	}
+	return entries, nil
+}
+
+// unset removes the values of key, or every value if none are given. A plain key without values also
+// removes the lines editing it with an array operator.
+func unset(entries []entry, key string, values []string) []entry {
+	op, name := SplitKey(key)
+	return without(entries, func(e entry) bool {
+		if len(values) > 0 && !contains(values, e.value) {
+			return false
+		}
+		if op == OpSet && len(values) == 0 {
+			_, n := SplitKey(e.key)
+			return n == name
+		}
+		return e.key == key
+	})
+}
+
+// SetValues returns the config content with the operator of key applied to the values of the section.
+// Only the lines that change are touched, the order of the lines matters to unreal's array operators.
+func SetValues(content []byte, section string, key string, values []string) ([]byte, error) {
+	return edit(content, section, func(entries []entry) ([]entry, error) {
+		return set(entries, key, values)
+	})
+}
+
+// UnsetValues returns the config content without the values of key in the section, the section is
+// removed once it has no keys left
+func UnsetValues(content []byte, section string, key string, values []string) ([]byte, error) {
+	return edit(content, section, func(entries []entry) ([]entry, error) {
+		return unset(entries, key, values), nil
+	})
+}
+
+// edit passes the Key=Value lines of the section to fn and writes back what it returns: kept lines are
+// changed in place, dropped lines are removed and new lines go after the line before them, or at the end
+// of the section. Every other line of the file stays as it was.
+func edit(content []byte, section string, fn func(entries []entry) ([]entry, error)) ([]byte, error) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	headers, owned, entries := readSection(lines, section)
+	entries, err := fn(entries)
+	if err != nil {
+		return nil, err
+	}
+	newline := "\n"
+	if bytes.Contains(content, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	kept := map[int]entry{}
+	added := map[int][]entry{}
+	anchor := -1
+	if len(headers) > 0 {
+		anchor = headers[0]
+	}
+	for _, e := range entries {
+		if e.line >= 0 {
+			kept[e.line] = e
+			anchor = e.line
+			continue
+		}
+		added[anchor] = append(added[anchor], e)
+	}
+
+	var b bytes.Buffer
+	addLines := func(entries []entry) {
+		if b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteString(newline)
+		}
+		for _, e := range entries {
+			b.WriteString(e.key + "=" + e.value + newline)
+		}
+	}
+	for i, line := range lines {
+		if owned[i] && len(entries) == 0 {
+			continue
+		}
+		if _, ok := entryLine(line); ok && owned[i] {
+			e, ok := kept[i]
+			if !ok {
+				continue
+			}
+			line = replaceLineValue(line, e.value)
+		}
+		b.Write(line)
+		addLines(added[i])
+	}
+	if len(headers) == 0 && len(entries) > 0 {
+		if b.Len() > 0 {
+			addLines(nil)
+			b.WriteString(newline)
+		}
+		b.WriteString("[" + section + "]" + newline)
+		addLines(added[-1])
+	}
+	return b.Bytes(), nil
+}
+
+// readSection returns the header lines of the section, every line belonging to it and its entries. A
+// section runs from its header to the next one and may appear more than once.
+func readSection(lines [][]byte, section string) ([]int, map[int]bool, []entry) {
+	var headers []int
+	owned := map[int]bool{}
+	var entries []entry
+	inSection := false
+	for i, line := range lines {
+		if name, ok := header(line); ok {
+			inSection = name == section
+			if inSection {
+				headers = append(headers, i)
+			}
+		}
+		if !inSection {
+			continue
+		}
+		owned[i] = true
+		if e, ok := entryLine(line); ok {
+			e.line = i
+			entries = append(entries, e)
+		}
+	}
+	return headers, owned, entries
+}
+
+// header returns the name of the section a line starts
+func header(line []byte) (string, bool) {
+	text := strings.TrimSpace(string(line))
+	if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+		return strings.TrimSpace(text[1 : len(text)-1]), true
+	}
+	return "", false
+}
+
+// entryLine parses a Key=Value line, comments and section headers are not entries
+func entryLine(line []byte) (entry, bool) {
+	text := strings.TrimSpace(string(line))
+	equals := strings.Index(text, "=")
+	if equals <= 0 || strings.HasPrefix(text, ";") || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "[") {
+		return entry{}, false
+	}
+	return entry{key: strings.TrimSpace(text[:equals]), value: strings.TrimSpace(text[equals+1:]), line: -1}, true
+}
+
+// insertAfter adds the entry after the last line editing the same array, or at the end of the section
+func insertAfter(entries []entry, name string, e entry) []entry {
+	at := len(entries)
+	for i, existing := range entries {
+		if _, n := SplitKey(existing.key); n == name {
+			at = i + 1
+		}
+	}
+	return append(entries[:at], append([]entry{e}, entries[at:]...)...)
+}
+
+func indexOf(entries []entry, key string) int {
+	for i, e := range entries {
+		if e.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func has(entries []entry, value string, keys ...string) bool {
+	for _, e := range entries {
+		if matching(value, keys...)(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func matching(value string, keys ...string) func(e entry) bool {
+	return func(e entry) bool {
+		return e.value == value && contains(keys, e.key)
+	}
+}
+
+func without(entries []entry, remove func(e entry) bool) []entry {
+	var answer []entry
+	for _, e := range entries {
+		if !remove(e) {
+			answer = append(answer, e)
+		}
+	}
+	return answer
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package ueini
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const packaging = `[/Script/UnrealEd.ProjectPackagingSettings]
+BuildConfiguration=PPBC_Development
++MapsToCook=(FilePath="/Game/Maps/Main")
++MapsToCook=(FilePath="/Game/Maps/Menu")
+bShareMaterialShaderCode=True
+`
+
+func TestSplitKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		op   Operator
+		name string
+	}{
+		{"MapsToCook", OpSet, "MapsToCook"},
+		{"+MapsToCook", OpAdd, "MapsToCook"},
+		{".MapsToCook", OpAddDuplicate, "MapsToCook"},
+		{"-MapsToCook", OpRemove, "MapsToCook"},
+		{"!MapsToCook", OpClear, "MapsToCook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			op, name := SplitKey(tt.key)
+			assert.Equal(t, tt.op, op)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func TestSetValues(t *testing.T) {
+	const section = "/Script/UnrealEd.ProjectPackagingSettings"
+	tests := []struct {
+		name    string
+		key     string
+		values  []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Replace",
+			key:    "BuildConfiguration",
+			values: []string{"PPBC_Shipping"},
+			want:   "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Shipping\n+MapsToCook=(FilePath=\"/Game/Maps/Main\")\n+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\nbShareMaterialShaderCode=True\n",
+		},
+		{
+			name:   "New key",
+			key:    "bCompressed",
+			values: []string{"True"},
+			want:   packaging + "bCompressed=True\n",
+		},
+		{
+			name:   "Add skips existing values",
+			key:    "+MapsToCook",
+			values: []string{`(FilePath="/Game/Maps/Menu")`, `(FilePath="/Game/Maps/Credits")`},
+			want:   "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\n+MapsToCook=(FilePath=\"/Game/Maps/Main\")\n+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\n+MapsToCook=(FilePath=\"/Game/Maps/Credits\")\nbShareMaterialShaderCode=True\n",
+		},
+		{
+			name:   "Add duplicate",
+			key:    ".MapsToCook",
+			values: []string{`(FilePath="/Game/Maps/Main")`},
+			want:   "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\n+MapsToCook=(FilePath=\"/Game/Maps/Main\")\n+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\n.MapsToCook=(FilePath=\"/Game/Maps/Main\")\nbShareMaterialShaderCode=True\n",
+		},
+		{
+			name:   "Remove",
+			key:    "-MapsToCook",
+			values: []string{`(FilePath="/Game/Maps/Main")`},
+			want:   "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\n+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\n-MapsToCook=(FilePath=\"/Game/Maps/Main\")\nbShareMaterialShaderCode=True\n",
+		},
+		{
+			name:   "Clear",
+			key:    "!MapsToCook",
+			values: []string{`(FilePath="/Game/Maps/Demo")`},
+			want:   "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\nbShareMaterialShaderCode=True\n!MapsToCook=ClearArray\n+MapsToCook=(FilePath=\"/Game/Maps/Demo\")\n",
+		},
+		{
+			name:    "Missing value",
+			key:     "+MapsToCook",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetValues([]byte(packaging), section, tt.key, tt.values)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestUnsetValues(t *testing.T) {
+	const section = "/Script/UnrealEd.ProjectPackagingSettings"
+
+	got, err := UnsetValues([]byte(packaging), section, "+MapsToCook", []string{`(FilePath="/Game/Maps/Main")`})
+	assert.NoError(t, err)
+	assert.Equal(t, "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\n+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\nbShareMaterialShaderCode=True\n", string(got))
+
+	got, err = UnsetValues([]byte(packaging), section, "MapsToCook", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[/Script/UnrealEd.ProjectPackagingSettings]\nBuildConfiguration=PPBC_Development\nbShareMaterialShaderCode=True\n", string(got))
+
+	got, err = UnsetValues([]byte("[A]\nKey=1\n\n[B]\nKey=2\n"), "A", "Key", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[B]\nKey=2\n", string(got))
+}
+
+func TestSetValues_KeepsOrder(t *testing.T) {
+	// unreal applies the operators line by line, moving !Foo after +Foo=y would leave Foo empty
+	const content = "[A]\n+Foo=x\n!Foo=ClearArray\n+Foo=y\nBar = 1 \n; comment\n\n[B]\nBar=1\n"
+
+	got, err := SetValues([]byte(content), "A", "Bar", []string{"2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[A]\n+Foo=x\n!Foo=ClearArray\n+Foo=y\nBar = 2 \n; comment\n\n[B]\nBar=1\n", string(got))
+
+	got, err = SetValues([]byte(content), "A", "Baz", []string{"3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[A]\n+Foo=x\n!Foo=ClearArray\n+Foo=y\nBar = 1 \nBaz=3\n; comment\n\n[B]\nBar=1\n", string(got))
+
+	got, err = SetValues([]byte(content), "A", "+Foo", []string{"z"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[A]\n+Foo=x\n!Foo=ClearArray\n+Foo=y\n+Foo=z\nBar = 1 \n; comment\n\n[B]\nBar=1\n", string(got))
+
+	got, err = SetValues([]byte("[A]\r\nFoo=1"), "C", "Foo", []string{"2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[A]\r\nFoo=1\r\n\r\n[C]\r\nFoo=2\r\n", string(got))
+}
@@ -0,0 +1,141 @@
+package ueini
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/ini.v1"
+)
+
+const (
+	// GeneralProjectSettings is the section of DefaultGame.ini describing the project
+	GeneralProjectSettings = "/Script/EngineSettings.GeneralProjectSettings"
+)
+
+// LoadOptions are the options unreal config files need: keys may repeat for arrays, even with the same
+// value, values keep their quotes and a ';' inside a value is not a comment
+var LoadOptions = ini.LoadOptions{
+	AllowShadows:               true,
+	AllowDuplicateShadowValues: true,
+	PreserveSurroundedQuote:    true,
+	IgnoreInlineComment:        true,
+}
+
+func init() {
+	// unreal writes Key=Value, aligning the '=' would rewrite every line of a file on save
+	ini.PrettyFormat = false
+}
+
+// Load reads an unreal config file from a path or from raw bytes
+func Load(source interface{}) (*ini.File, error) {
+	cfg, err := ini.LoadSources(LoadOptions, source)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading ini")
+	}
+	return cfg, nil
+}
+
+// LoadOrEmpty reads the config file at path, or returns an empty config if it doesn't exist yet
+func LoadOrEmpty(path string) (*ini.File, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ini.Empty(LoadOptions), nil
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s", path)
+	}
+	return cfg, nil
+}
+
+// Bytes renders the config file
+func Bytes(cfg *ini.File) ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := cfg.WriteTo(&b); err != nil {
+		return nil, errors.Wrap(err, "writing ini")
+	}
+	return b.Bytes(), nil
+}
+
+// Save writes the config file to path
+func Save(cfg *ini.File, path string) error {
+	data, err := Bytes(cfg)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	return nil
+}
+
+// FindKey returns the first *.ini file in dir holding a value for key in section, or an empty string if
+// none of them do
+func FindKey(dir string, section string, key string) (string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", dir)
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".ini" {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		cfg, err := Load(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "loading %s", path)
+		}
+		if cfg.Section(section).Key(key).String() != "" {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// SetKey returns the config content with key in section set to value, adding the section or key if needed.
+// The line of the key is changed in place.
+func SetKey(content []byte, section string, key string, value string) ([]byte, error) {
+	return SetValues(content, section, key, []string{value})
+}
+
+// ReplaceValue changes the first value of key in section in place, keeping every other byte of the file
+// as it was. It returns false if the section has no such key.
+func ReplaceValue(content []byte, section string, key string, value string) ([]byte, bool) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	current := ""
+	for i, line := range lines {
+		if name, ok := header(line); ok {
+			current = name
+			continue
+		}
+		if current != section {
+			continue
+		}
+		e, ok := entryLine(line)
+		if !ok || !strings.EqualFold(e.key, key) {
+			continue
+		}
+		lines[i] = replaceLineValue(line, value)
+		return bytes.Join(lines, nil), true
+	}
+	return content, false
+}
+
+// replaceLineValue returns a Key=Value line with the value replaced, keeping the spacing and line ending
+func replaceLineValue(line []byte, value string) []byte {
+	start := bytes.Index(line, []byte("=")) + 1
+	for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	end := len(bytes.TrimRight(line, " \t\r\n"))
+	if end < start {
+		end = start
+	}
+	if string(line[start:end]) == value {
+		return line
+	}
+	return append(append(append([]byte{}, line[:start]...), value...), line[end:]...)
+}
@@ -0,0 +1,87 @@
+package ueini
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultGame = `[/Script/EngineSettings.GeneralProjectSettings]
+ProjectID=0C9A1B2C4D5E6F708192A3B4C5D6E7F8
+ProjectVersion=1.0.0
+CopyrightNotice="Copyright Me; All rights reserved"
++MapsToCook=(FilePath="/Game/Maps/Main")
++MapsToCook=(FilePath="/Game/Maps/Menu")
+`
+
+func TestRoundTrip(t *testing.T) {
+	cfg, err := Load([]byte(defaultGame))
+	assert.NoError(t, err)
+
+	section := cfg.Section("/Script/EngineSettings.GeneralProjectSettings")
+	assert.Equal(t, `"Copyright Me; All rights reserved"`, section.Key("CopyrightNotice").String())
+	assert.Equal(t, []string{`(FilePath="/Game/Maps/Main")`, `(FilePath="/Game/Maps/Menu")`}, section.Key("+MapsToCook").ValueWithShadows())
+
+	data, err := Bytes(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultGame, string(data))
+}
+
+func TestLoadOrEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Install.ini")
+	cfg, err := LoadOrEmpty(path)
+	assert.NoError(t, err)
+	cfg.Section("Installations").Key("{ABC}").SetValue("/engine")
+	assert.NoError(t, Save(cfg, path))
+
+	cfg, err = LoadOrEmpty(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "/engine", cfg.Section("Installations").Key("{ABC}").String())
+}
+
+func TestFindKey(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultEngine.ini"), []byte("[/Script/Engine.Engine]\nA=1\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "DefaultGame.ini"), []byte(defaultGame), 0644))
+
+	got, err := FindKey(dir, "/Script/EngineSettings.GeneralProjectSettings", "ProjectVersion")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "DefaultGame.ini"), got)
+
+	got, err = FindKey(dir, "/Script/EngineSettings.GeneralProjectSettings", "ProjectName")
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	_, err = FindKey(filepath.Join(dir, "missing"), "a", "b")
+	assert.Error(t, err)
+}
+
+func TestSetKey(t *testing.T) {
+	got, err := SetKey([]byte(defaultGame), GeneralProjectSettings, "ProjectVersion", "1.1.0")
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "\nProjectVersion=1.1.0\n")
+	assert.Contains(t, string(got), "+MapsToCook=(FilePath=\"/Game/Maps/Menu\")\n")
+
+	got, err = SetKey([]byte{}, "New", "Key", "Value")
+	assert.NoError(t, err)
+	assert.Equal(t, "[New]\nKey=Value\n", string(got))
+}
+
+func TestReplaceValue(t *testing.T) {
+	content := "; comment\r\n[/Script/EngineSettings.GeneralProjectSettings]\r\nProjectName = Shooter\r\nProjectVersion=1.0.0\r\n\r\n[Other]\r\nProjectVersion=9.9.9\r\n"
+
+	got, ok := ReplaceValue([]byte(content), GeneralProjectSettings, "projectversion", "1.1.0")
+	assert.True(t, ok)
+	assert.Equal(t, strings.Replace(content, "ProjectVersion=1.0.0", "ProjectVersion=1.1.0", 1), string(got))
+
+	got, ok = ReplaceValue([]byte(content), GeneralProjectSettings, "ProjectName", "Arena")
+	assert.True(t, ok)
+	assert.Contains(t, string(got), "\r\nProjectName = Arena\r\n")
+
+	got, ok = ReplaceValue([]byte(content), GeneralProjectSettings, "CompanyName", "Epic")
+	assert.False(t, ok)
+	assert.Equal(t, content, string(got))
+}
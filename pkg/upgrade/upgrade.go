@@ -0,0 +1,56 @@
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/edit"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/pkg/errors"
+)
+
+// PlanEngineUpgrade plans every edit needed to move a project to another engine: the EngineAssociation
+// of the .uproject, the EngineVersion of first-party plugins and the build settings of each Target.cs
+func PlanEngineUpgrade(project *descriptor.Project, association string, engine *engines.BuildVersion) (*edit.Plan, error) {
+	plan := edit.NewPlan()
+
+	content, err := descriptor.SetField(project.Content, descriptor.EngineAssociationKey, association)
+	if err != nil {
+		return nil, errors.Wrapf(err, "updating %s", project.Path)
+	}
+	if err := plan.Update(project.Path, content, fmt.Sprintf("%s %s", descriptor.EngineAssociationKey, association)); err != nil {
+		return nil, err
+	}
+
+	plugins, err := descriptor.LoadPlugins(project.Dir())
+	if err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if !plugin.IsFirstParty() || plugin.CheckCompatibility(engine) == descriptor.Unspecified {
+			continue
+		}
+		content, err := descriptor.SetField(plugin.Content, descriptor.EngineVersionKey, engine.String())
+		if err != nil {
+			return nil, errors.Wrapf(err, "updating %s", plugin.Path)
+		}
+		if err := plan.Update(plugin.Path, content, fmt.Sprintf("%s %s", descriptor.EngineVersionKey, engine)); err != nil {
+			return nil, err
+		}
+	}
+
+	targets, err := descriptor.FindTargetRules(project.Dir())
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		content, err := plan.Content(target)
+		if err != nil {
+			return nil, err
+		}
+		if err := plan.Update(target, descriptor.SetTargetRulesVersions(content, engine), "build settings"); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
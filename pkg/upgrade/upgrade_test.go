@@ -0,0 +1,47 @@
+package upgrade
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/descriptor"
+	"github.com/Benbentwo/UnrealGameVersionUpdater/pkg/engines"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestPlanEngineUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "MyGame.uproject"), "{\n\t\"FileVersion\": 3,\n\t\"EngineAssociation\": \"5.2\"\n}\n")
+	writeFile(t, filepath.Join(dir, "Plugins", "Tools", "Tools.uplugin"), "{\n\t\"EngineVersion\": \"5.2.0\"\n}\n")
+	writeFile(t, filepath.Join(dir, "Plugins", "Marketplace", "Shop", "Shop.uplugin"), "{\n\t\"EngineVersion\": \"5.2.0\"\n}\n")
+	writeFile(t, filepath.Join(dir, "Plugins", "Any", "Any.uplugin"), "{\n\t\"FileVersion\": 3\n}\n")
+	writeFile(t, filepath.Join(dir, "Source", "MyGame.Target.cs"), "\t\tDefaultBuildSettings = BuildSettingsVersion.V2;\n\t\tIncludeOrderVersion = EngineIncludeOrderVersion.Unreal5_2;\n")
+
+	project, err := descriptor.LoadProject(filepath.Join(dir, "MyGame.uproject"))
+	assert.NoError(t, err)
+
+	plan, err := PlanEngineUpgrade(project, "5.3", &engines.BuildVersion{MajorVersion: 5, MinorVersion: 3})
+	assert.NoError(t, err)
+
+	var changed []string
+	for _, change := range plan.Changes() {
+		rel, _ := filepath.Rel(dir, change.Path)
+		changed = append(changed, filepath.ToSlash(rel))
+	}
+	assert.Equal(t, []string{"MyGame.uproject", "Plugins/Tools/Tools.uplugin", "Source/MyGame.Target.cs"}, changed)
+	assert.Contains(t, plan.Diff(), "+\t\"EngineAssociation\": \"5.3\"\n")
+	assert.Contains(t, plan.Diff(), "+\t\"EngineVersion\": \"5.3.0\"\n")
+	assert.Contains(t, plan.Diff(), "+\t\tIncludeOrderVersion = EngineIncludeOrderVersion.Unreal5_3;\n")
+
+	assert.NoError(t, plan.Apply())
+	data, err := ioutil.ReadFile(filepath.Join(dir, "Source", "MyGame.Target.cs"))
+	assert.NoError(t, err)
+	assert.Equal(t, "\t\tDefaultBuildSettings = BuildSettingsVersion.V4;\n\t\tIncludeOrderVersion = EngineIncludeOrderVersion.Unreal5_3;\n", string(data))
+}
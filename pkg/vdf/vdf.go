@@ -0,0 +1,290 @@
+package vdf
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Node is a key of a Valve KeyValues (VDF) document, such as the app_build files of SteamCMD. It remembers
+// where it lives in the original document so a value can be replaced without reformatting anything around it.
+type Node struct {
+	Key      string
+	KeyStart int
+	// KeyEnd is the offset after the key, including its quotes
+	KeyEnd int
+	// Value is the text of a string value, empty for an object
+	Value string
+	// Start and End delimit the value, including quotes or braces
+	Start    int
+	End      int
+	Children []*Node
+	object   bool
+}
+
+// IsObject returns true if the node holds keys rather than a string
+func (n *Node) IsObject() bool {
+	return n.object
+}
+
+// Child returns the child with the given key, keys are matched ignoring case the way Steam does
+func (n *Node) Child(key string) *Node {
+	for _, child := range n.Children {
+		if strings.EqualFold(child.Key, key) {
+			return child
+		}
+	}
+	return nil
+}
+
+// Lookup resolves a path of keys separated by dots such as `AppBuild.Desc`
+func (n *Node) Lookup(path string) (*Node, error) {
+	current := n
+	for _, key := range strings.Split(path, ".") {
+		if !current.object {
+			return nil, errors.Errorf("%s: %s used on a value that is not an object", path, key)
+		}
+		next := current.Child(key)
+		if next == nil {
+			return nil, &NotFoundError{Path: path, Key: key}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// NotFoundError is returned when a path does not exist in a document
+type NotFoundError struct {
+	Path string
+	Key  string
+}
+
+func (e *NotFoundError) Error() string {
+	return "key " + e.Key + " not found for path " + e.Path
+}
+
+// IsNotFound returns true if the error is a NotFoundError
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*NotFoundError)
+	return ok
+}
+
+// Parse parses a document keeping the byte offsets of every key and value. The root node is an object
+// without braces holding the top level keys. Strings are read as written: like SteamCMD, backslashes are
+// not escapes so paths such as "..\content\" keep working.
+func Parse(data []byte) (*Node, error) {
+	p := &parser{data: data}
+	root := &Node{object: true, End: len(data)}
+	if err := p.members(root, false); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// GetString returns the string value at the given path
+func GetString(data []byte, path string) (string, error) {
+	root, err := Parse(data)
+	if err != nil {
+		return "", err
+	}
+	node, err := root.Lookup(path)
+	if err != nil {
+		return "", err
+	}
+	if node.object {
+		return "", errors.Errorf("%s is an object", path)
+	}
+	return node.Value, nil
+}
+
+// Set replaces the string value at the given path, keeping comments and layout. If the last key of the path
+// is missing from its object it is added after the last key of that object with the same indentation.
+func Set(data []byte, path string, value string) ([]byte, error) {
+	if strings.ContainsAny(value, "\"\r\n") {
+		return nil, errors.Errorf("%s: %q can't be written to a VDF string", path, value)
+	}
+	root, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	encoded := `"` + value + `"`
+	node, err := root.Lookup(path)
+	if err == nil {
+		if node.object {
+			return nil, errors.Errorf("%s is an object", path)
+		}
+		return splice(data, node.Start, node.End, encoded), nil
+	}
+	if !IsNotFound(err) {
+		return nil, err
+	}
+	keys := strings.Split(path, ".")
+	parent := root
+	if len(keys) > 1 {
+		if parent, err = root.Lookup(strings.Join(keys[:len(keys)-1], ".")); err != nil {
+			return nil, err
+		}
+		if !parent.object {
+			return nil, errors.Errorf("%s: parent is not an object", path)
+		}
+	}
+	return insert(data, parent, keys[len(keys)-1], encoded), nil
+}
+
+func splice(data []byte, start, end int, replacement string) []byte {
+	answer := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	answer = append(answer, data[:start]...)
+	answer = append(answer, replacement...)
+	return append(answer, data[end:]...)
+}
+
+// insert adds a key on its own line after the last key of an object, copying its indentation and the
+// separator between key and value of the closest string value
+func insert(data []byte, object *Node, key string, encoded string) []byte {
+	newline := "\n"
+	if strings.Contains(string(data), "\r\n") {
+		newline = "\r\n"
+	}
+	indent, separator := "", "\t\t"
+	after := len(data)
+	if braced := object.Start < len(data) && data[object.Start] == '{'; braced {
+		indent, after = lineIndent(data, object.Start)+"\t", object.Start+1
+	}
+	if len(object.Children) > 0 {
+		last := object.Children[len(object.Children)-1]
+		indent = lineIndent(data, last.KeyStart)
+		for i := len(object.Children) - 1; i >= 0; i-- {
+			child := object.Children[i]
+			if between := string(data[child.KeyEnd:child.Start]); !child.object && strings.TrimSpace(between) == "" {
+				separator = between
+				break
+			}
+		}
+		after = last.End
+	}
+	// keep a comment at the end of the line on that line
+	for after < len(data) && data[after] != '\n' && data[after] != '\r' {
+		after++
+	}
+	line := newline + indent + `"` + key + `"` + separator + encoded
+	if after == 0 {
+		line = strings.TrimPrefix(line, newline) + newline
+	}
+	return splice(data, after, after, line)
+}
+
+func lineIndent(data []byte, pos int) string {
+	start := pos
+	for start > 0 && (data[start-1] == ' ' || data[start-1] == '\t') {
+		start--
+	}
+	return string(data[start:pos])
+}
+
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(string(p.data[:p.pos]), "\n") + 1
+	return errors.Errorf("invalid VDF on line %d: %s", line, errors.Errorf(format, args...))
+}
+
+// skipBlank skips whitespace and // comments
+func (p *parser) skipBlank() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		case c == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '/':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// members parses keys until the closing brace of the object, or the end of the input at the top level
+func (p *parser) members(object *Node, braced bool) error {
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			if braced {
+				return p.errorf("unterminated object %s", object.Key)
+			}
+			return nil
+		}
+		if p.data[p.pos] == '}' {
+			if !braced {
+				return p.errorf("unexpected }")
+			}
+			p.pos++
+			object.End = p.pos
+			return nil
+		}
+		node := &Node{KeyStart: p.pos}
+		key, err := p.token()
+		if err != nil {
+			return err
+		}
+		node.Key, node.KeyEnd = key, p.pos
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return p.errorf("key %s has no value", key)
+		}
+		node.Start = p.pos
+		if p.data[p.pos] == '{' {
+			node.object = true
+			p.pos++
+			if err := p.members(node, true); err != nil {
+				return err
+			}
+		} else {
+			if node.Value, err = p.token(); err != nil {
+				return err
+			}
+			node.End = p.pos
+		}
+		p.skipConditional()
+		object.Children = append(object.Children, node)
+	}
+}
+
+// skipConditional skips a platform condition such as [$WIN32] after a value
+func (p *parser) skipConditional() {
+	start := p.pos
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '[' {
+		if end := strings.IndexAny(string(p.data[p.pos:]), "]\n"); end >= 0 && p.data[p.pos+end] == ']' {
+			p.pos += end + 1
+			return
+		}
+	}
+	p.pos = start
+}
+
+// token reads a quoted or unquoted string
+func (p *parser) token() (string, error) {
+	if p.data[p.pos] == '"' {
+		end := strings.IndexByte(string(p.data[p.pos+1:]), '"')
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+		value := string(p.data[p.pos+1 : p.pos+1+end])
+		p.pos += end + 2
+		return value, nil
+	}
+	start := p.pos
+	for p.pos < len(p.data) && strings.IndexByte(" \t\r\n{}\"", p.data[p.pos]) < 0 {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.errorf("unexpected character %q", p.data[p.pos])
+	}
+	return string(p.data[start:p.pos]), nil
+}
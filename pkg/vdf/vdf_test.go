@@ -0,0 +1,85 @@
+package vdf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const appBuild = `"AppBuild"
+{
+	"AppID"	"1000" // your AppID
+	"Desc"	"Your build description here" // internal description for this build
+	"ContentRoot"	"..\content\"
+	"BuildOutput"	"..\output\"
+	"Depots"
+	{
+		"1001"	"depot_build_1001.vdf"
+	}
+	"Preview" "0" [$WIN32]
+}
+`
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		path    string
+		value   string
+		before  string
+		after   string
+		wantErr bool
+	}{
+		{"Replace", appBuild, "AppBuild.Desc", "Shooter 1.2.0", `"Desc"	"Your build description here" //`, `"Desc"	"Shooter 1.2.0" //`, false},
+		{"Ignores case", appBuild, "appbuild.desc", "Shooter 1.2.0", `"Your build description here"`, `"Shooter 1.2.0"`, false},
+		{"Nested", appBuild, "AppBuild.Depots.1001", "depot.vdf", `"depot_build_1001.vdf"`, `"depot.vdf"`, false},
+		{"Conditional", appBuild, "AppBuild.Preview", "1", `"Preview" "0" [$WIN32]`, `"Preview" "1" [$WIN32]`, false},
+		{"Insert after a condition", appBuild, "AppBuild.SetLive", "beta", "\t\"Preview\" \"0\" [$WIN32]\n", "\t\"Preview\" \"0\" [$WIN32]\n\t\"SetLive\" \"beta\"\n", false},
+		{"Insert into nested", appBuild, "AppBuild.Depots.1002", "depot_build_1002.vdf", "\t\t\"1001\"\t\"depot_build_1001.vdf\"\n", "\t\t\"1001\"\t\"depot_build_1001.vdf\"\n\t\t\"1002\"\t\"depot_build_1002.vdf\"\n", false},
+		{"Insert after an object", "\"AppBuild\"\n{\n\t\"Desc\"  \"x\"\n\t\"Depots\"\n\t{\n\t}\n}\n", "AppBuild.SetLive", "beta", "\t}\n", "\t}\n\t\"SetLive\"  \"beta\"\n", false},
+		{"Insert into empty", "\"AppBuild\"\n{\n}\n", "AppBuild.SetLive", "beta", "{\n", "{\n\t\"SetLive\"\t\t\"beta\"\n", false},
+		{"Insert at top level", "", "SetLive", "beta", "", "\"SetLive\"\t\t\"beta\"\n", false},
+		{"Windows line endings", "\"AppBuild\"\r\n{\r\n\t\"Desc\" \"x\"\r\n}\r\n", "AppBuild.SetLive", "beta", "\"x\"\r\n", "\"x\"\r\n\t\"SetLive\" \"beta\"\r\n", false},
+		{"Object", appBuild, "AppBuild.Depots", "x", "", "", true},
+		{"Missing parent", appBuild, "AppBuild.Missing.Desc", "x", "", "", true},
+		{"Quote", appBuild, "AppBuild.Desc", `say "hi"`, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Set([]byte(tt.data), tt.path, tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, "%v", err)
+			if err == nil {
+				assert.Equal(t, strings.Replace(tt.data, tt.before, tt.after, 1), string(got))
+				value, err := GetString(got, tt.path)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.value, value)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	root, err := Parse([]byte(appBuild))
+	assert.NoError(t, err)
+	build, err := root.Lookup("AppBuild")
+	assert.NoError(t, err)
+	assert.True(t, build.IsObject())
+	var keys []string
+	for _, child := range build.Children {
+		keys = append(keys, child.Key)
+	}
+	assert.Equal(t, []string{"AppID", "Desc", "ContentRoot", "BuildOutput", "Depots", "Preview"}, keys)
+	assert.Equal(t, `..\content\`, build.Child("contentroot").Value)
+
+	unquoted, err := Parse([]byte("AppBuild { AppID 1000 }"))
+	assert.NoError(t, err)
+	id, err := unquoted.Lookup("AppBuild.AppID")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", id.Value)
+
+	for _, data := range []string{`"AppBuild" {`, `"AppBuild" }`, `"AppBuild" "x`, `"AppBuild"`} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}